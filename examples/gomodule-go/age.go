@@ -0,0 +1,94 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.bytecodealliance.org/cm"
+)
+
+type GetModuleAgeResult = cm.Result[string, string, string]
+
+// defaultMaxAgeDays is how old a module's latest release may be before it is
+// flagged as potentially unmaintained.
+const defaultMaxAgeDays = 365
+
+// moduleAge describes how long ago a module's latest version was published.
+// The age fields are omitted when the proxy's timestamp cannot be parsed.
+type moduleAge struct {
+	Version      string `json:"version"`
+	Time         string `json:"time"`
+	Published    string `json:"published,omitempty"`
+	AgeDays      *int   `json:"age_days,omitempty"`
+	Unmaintained *bool  `json:"unmaintained,omitempty"`
+}
+
+// getModuleAge reports when the latest version of every module was published
+// and flags modules older than maxAgeDays (defaultMaxAgeDays when 0).
+func getModuleAge(ctx context.Context, moduleNames string, maxAgeDays uint32) GetModuleAgeResult {
+	if maxAgeDays == 0 {
+		maxAgeDays = defaultMaxAgeDays
+	}
+
+	now := time.Now()
+	modules, invalid, err := normalizeModules(moduleNames)
+	if err != nil {
+		return fail[GetModuleAgeResult](err)
+	}
+	batch := newBatchResult(invalid)
+
+	for _, fetched := range fetchModules(ctx, modules, escapedLatestPath) {
+		var info struct {
+			Version string
+			Time    string
+		}
+
+		err := fetched.err
+		if err == nil {
+			err = json.Unmarshal(fetched.data, &info)
+		}
+		if err != nil {
+			batch.addError(fetched.module, err)
+			continue
+		}
+
+		age := moduleAge{Version: info.Version, Time: info.Time}
+		if published, err := time.Parse(time.RFC3339, info.Time); err == nil {
+			days := int(now.Sub(published).Hours() / 24)
+			unmaintained := days > int(maxAgeDays)
+			age.Published = "published " + relativeAge(now.Sub(published))
+			age.AgeDays = &days
+			age.Unmaintained = &unmaintained
+		}
+		batch.add(fetched.module, age)
+	}
+	return batchOutput[GetModuleAgeResult](batch, "get module ages")
+}
+
+// relativeAge renders d as "today", "3 days ago", "2 months ago" or
+// "1 year ago", using 30-day months and 365-day years.
+func relativeAge(d time.Duration) string {
+	days := int(d.Hours() / 24)
+	switch {
+	case days < 1:
+		return "today"
+	case days < 30:
+		return plural(days, "day") + " ago"
+	case days < 365:
+		return plural(days/30, "month") + " ago"
+	default:
+		return plural(days/365, "year") + " ago"
+	}
+}
+
+func plural(n int, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
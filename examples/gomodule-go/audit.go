@@ -0,0 +1,119 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.bytecodealliance.org/cm"
+	"golang.org/x/mod/modfile"
+)
+
+type AuditGoModResult = cm.Result[string, string, string]
+
+// auditedRequirement compares one requirement of an audited go.mod with the
+// latest version of the module. Status is "up_to_date", "outdated",
+// "unresolvable" for modules the proxy does not know or may not be asked
+// about, such as private modules, or "error".
+type auditedRequirement struct {
+	Path     string `json:"path"`
+	Current  string `json:"current"`
+	Latest   string `json:"latest,omitempty"`
+	Bump     string `json:"bump,omitempty"`
+	Indirect bool   `json:"indirect"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+}
+
+// goModAudit is the result of auditGoMod.
+type goModAudit struct {
+	Module       string               `json:"module,omitempty"`
+	Requirements []auditedRequirement `json:"requirements"`
+	Outdated     int                  `json:"outdated"`
+	Unresolvable int                  `json:"unresolvable"`
+}
+
+// auditGoMod parses the contents of a go.mod file and compares each direct
+// requirement, and each indirect one when includeIndirect is set, with the
+// module's latest version. Requirements are sorted by path so the output of
+// repeated audits can be diffed.
+func auditGoMod(ctx context.Context, goModContents string, includeIndirect bool) AuditGoModResult {
+	file, err := modfile.Parse("go.mod", []byte(goModContents), nil)
+	if err != nil {
+		return fail[AuditGoModResult](describe(err, "Failed to parse go.mod: %s", parseErrorText(err)))
+	}
+
+	var requirements []auditedRequirement
+	for _, req := range file.Require {
+		if req.Indirect && !includeIndirect {
+			continue
+		}
+		requirements = append(requirements, auditedRequirement{
+			Path:     req.Mod.Path,
+			Current:  req.Mod.Version,
+			Indirect: req.Indirect,
+		})
+	}
+	sort.Slice(requirements, func(i, j int) bool {
+		return requirements[i].Path < requirements[j].Path
+	})
+
+	paths := make([]string, len(requirements))
+	for i, req := range requirements {
+		paths[i] = req.Path
+	}
+
+	forEachModule(ctx, paths, func(i int, path string) {
+		req := &requirements[i]
+		latest, err := resolveLatestVersion(ctx, path)
+		var private *privateModuleError
+		switch {
+		case err == nil:
+		case isNotFound(err) || errors.As(err, &private):
+			req.Status = "unresolvable"
+			req.Error = err.Error()
+			return
+		default:
+			req.Status = "error"
+			req.Error = err.Error()
+			return
+		}
+
+		req.Latest = latest
+		req.Bump = changeKind(req.Current, latest)
+		if req.Bump == "same" || req.Bump == "downgrade" {
+			// A pin newer than @latest, e.g. a prerelease, needs no upgrade.
+			req.Status = "up_to_date"
+		} else {
+			req.Status = "outdated"
+		}
+	})
+
+	audit := goModAudit{Requirements: requirements}
+	if audit.Requirements == nil {
+		audit.Requirements = []auditedRequirement{}
+	}
+	if file.Module != nil {
+		audit.Module = file.Module.Mod.Path
+	}
+	for _, req := range requirements {
+		switch req.Status {
+		case "outdated":
+			audit.Outdated++
+		case "unresolvable":
+			audit.Unresolvable++
+		}
+	}
+
+	jsonData, err := json.Marshal(audit)
+	if err != nil {
+		return fail[AuditGoModResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[AuditGoModResult](string(jsonData))
+}
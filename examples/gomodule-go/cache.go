@@ -0,0 +1,160 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"container/list"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// defaultCacheTTL is how long a successful GET response is reused unless
+// overridden by the GOMODULE_CACHE_TTL environment variable.
+const defaultCacheTTL = 5 * time.Minute
+
+// maxCacheEntries bounds the response cache; the least recently used entry
+// is evicted to make room for a new one.
+const maxCacheEntries = 1000
+
+// cacheTTL is the lifetime of cached responses; 0 disables the cache.
+var cacheTTL = defaultCacheTTL
+
+func init() {
+	if value := os.Getenv("GOMODULE_CACHE_TTL"); value != "" {
+		if ttl, err := time.ParseDuration(value); err == nil && ttl >= 0 {
+			cacheTTL = ttl
+		}
+	}
+}
+
+// cacheEntry is a cached response body and the time it stops being valid,
+// which is zero for responses that never change. Expired entries with an
+// ETag are kept so that they can be revalidated.
+type cacheEntry struct {
+	url     string
+	data    []byte
+	etag    string
+	expires time.Time
+}
+
+// responseCache holds successful GET responses keyed by URL, most recently
+// used first. The component instance may be reused across tool calls, and
+// the batch tools fetch concurrently, so access is guarded by a mutex.
+// Expired entries are evicted lazily when they are next looked up.
+var responseCache = struct {
+	sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}{entries: make(map[string]*list.Element), order: list.New()}
+
+// cachedResponse returns the cached body for url, if it has not expired.
+func cachedResponse(url string) ([]byte, bool) {
+	if cacheTTL == 0 {
+		return nil, false
+	}
+
+	responseCache.Lock()
+	defer responseCache.Unlock()
+
+	element, ok := responseCache.entries[url]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*cacheEntry)
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		if entry.etag == "" {
+			responseCache.order.Remove(element)
+			delete(responseCache.entries, url)
+		}
+		return nil, false
+	}
+	responseCache.order.MoveToFront(element)
+	return entry.data, true
+}
+
+// staleResponse returns the expired body cached for url and its ETag, or
+// an empty ETag when there is nothing to revalidate.
+func staleResponse(url string) ([]byte, string) {
+	if cacheTTL == 0 {
+		return nil, ""
+	}
+
+	responseCache.Lock()
+	defer responseCache.Unlock()
+
+	element, ok := responseCache.entries[url]
+	if !ok {
+		return nil, ""
+	}
+	entry := element.Value.(*cacheEntry)
+	return entry.data, entry.etag
+}
+
+// cacheResponse stores data, with its ETag if the server sent one, as the
+// response for url: for cacheTTL, or for as long as it is not evicted if url
+// is immutable. Storing it again renews it.
+func cacheResponse(url string, data []byte, etag string) {
+	if cacheTTL == 0 {
+		return
+	}
+
+	var expires time.Time
+	if !isImmutableURL(url) {
+		expires = time.Now().Add(cacheTTL)
+	}
+
+	responseCache.Lock()
+	defer responseCache.Unlock()
+
+	if element, ok := responseCache.entries[url]; ok {
+		entry := element.Value.(*cacheEntry)
+		entry.data, entry.etag, entry.expires = data, etag, expires
+		responseCache.order.MoveToFront(element)
+		return
+	}
+
+	responseCache.entries[url] = responseCache.order.PushFront(&cacheEntry{url: url, data: data, etag: etag, expires: expires})
+	if responseCache.order.Len() > maxCacheEntries {
+		oldest := responseCache.order.Back()
+		responseCache.order.Remove(oldest)
+		delete(responseCache.entries, oldest.Value.(*cacheEntry).url)
+		cacheEvictions.Add(1)
+	}
+}
+
+// cacheSize returns how many responses are cached.
+func cacheSize() int {
+	responseCache.Lock()
+	defer responseCache.Unlock()
+	return responseCache.order.Len()
+}
+
+// isImmutableURL reports whether url is the .info or .mod file of a proxy
+// at a full semantic version, such as ".../@v/v1.2.3.mod". The proxy serves
+// those unchanged forever, unlike @latest, @v/list and queries such as
+// ".../@v/master.info" or ".../@v/v1.2.info".
+func isImmutableURL(url string) bool {
+	_, file, ok := strings.Cut(url, "/@v/")
+	if !ok {
+		return false
+	}
+
+	escaped, found := strings.CutSuffix(file, ".info")
+	if !found {
+		if escaped, found = strings.CutSuffix(file, ".mod"); !found {
+			return false
+		}
+	}
+
+	version, err := module.UnescapeVersion(escaped)
+	if err != nil || !semver.IsValid(version) {
+		return false
+	}
+	return semver.Canonical(version) == strings.TrimSuffix(version, "+incompatible")
+}
@@ -0,0 +1,218 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.bytecodealliance.org/cm"
+	"golang.org/x/mod/semver"
+)
+
+type FilterVersionsResult = cm.Result[string, string, string]
+
+// versionBound is a single comparison such as ">= v1.6.0".
+type versionBound struct {
+	op      string
+	version string
+}
+
+func (b versionBound) matches(v string) bool {
+	cmp := semver.Compare(v, b.version)
+	switch b.op {
+	case ">=":
+		return cmp >= 0
+	case ">":
+		return cmp > 0
+	case "<=":
+		return cmp <= 0
+	case "<":
+		return cmp < 0
+	default:
+		return cmp == 0
+	}
+}
+
+// versionConstraint is a conjunction of bounds. Prereleases only match when
+// the constraint itself names one, as in ">=v2.0.0-rc.1".
+type versionConstraint struct {
+	bounds      []versionBound
+	prereleases bool
+}
+
+func (c versionConstraint) matches(v string) bool {
+	if !semver.IsValid(v) || (!c.prereleases && semver.Prerelease(v) != "") {
+		return false
+	}
+	for _, bound := range c.bounds {
+		if !bound.matches(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseConstraint parses clauses such as ">=1.6.0 <1.9.0" or ">=1.6, <2",
+// separated by commas or spaces. Each clause is a version, optionally
+// prefixed by =, >, >=, <, <=, ~ (same minor) or ^ (same major, or same
+// minor below v1). Versions may omit the leading "v" and trailing
+// components, so "1.6" means v1.6.0.
+func parseConstraint(constraint string) (versionConstraint, error) {
+	var c versionConstraint
+	fields := strings.FieldsFunc(constraint, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+
+	// Allow a space between an operator and its version, as in ">= 1.6.0".
+	var clauses []string
+	for i := 0; i < len(fields); i++ {
+		clause := fields[i]
+		if strings.Trim(clause, "<>=~^") == "" && i+1 < len(fields) {
+			i++
+			clause += fields[i]
+		}
+		clauses = append(clauses, clause)
+	}
+	if len(clauses) == 0 {
+		return c, fmt.Errorf("constraint must not be empty")
+	}
+
+	for _, clause := range clauses {
+		op, rest := splitOperator(clause)
+		version, precision, err := parseConstraintVersion(rest)
+		if err != nil {
+			return c, fmt.Errorf("invalid clause %q: %w", clause, err)
+		}
+		if semver.Prerelease(version) != "" {
+			c.prereleases = true
+		}
+
+		switch op {
+		case "~":
+			c.bounds = append(c.bounds, versionBound{">=", version}, versionBound{"<", nextVersion(version, min(precision, 2))})
+		case "^":
+			c.bounds = append(c.bounds, versionBound{">=", version}, versionBound{"<", nextVersion(version, caretPrecision(version, precision))})
+		case "", "=":
+			if precision < 3 {
+				// "=1.6" matches any v1.6.x.
+				c.bounds = append(c.bounds, versionBound{">=", version}, versionBound{"<", nextVersion(version, precision)})
+			} else {
+				c.bounds = append(c.bounds, versionBound{"=", version})
+			}
+		default:
+			c.bounds = append(c.bounds, versionBound{op, version})
+		}
+	}
+	return c, nil
+}
+
+// splitOperator separates a clause's leading operator from its version.
+func splitOperator(clause string) (string, string) {
+	for _, op := range []string{">=", "<=", ">", "<", "=", "~", "^"} {
+		if strings.HasPrefix(clause, op) {
+			return op, clause[len(op):]
+		}
+	}
+	return "", clause
+}
+
+// parseConstraintVersion canonicalizes a possibly partial version and
+// reports how many of major, minor and patch were given.
+func parseConstraintVersion(s string) (string, int, error) {
+	if s == "" {
+		return "", 0, fmt.Errorf("missing version")
+	}
+	v := canonicalVersion(s)
+	if !semver.IsValid(v) {
+		return "", 0, fmt.Errorf("%q is not a semantic version", s)
+	}
+
+	core := strings.TrimPrefix(v, "v")
+	if i := strings.IndexAny(core, "-+"); i >= 0 {
+		core = core[:i]
+	}
+	return semver.Canonical(v), strings.Count(core, ".") + 1, nil
+}
+
+// caretPrecision returns how many leading components ^version keeps fixed:
+// the major version, or the first non-zero component below v1.
+func caretPrecision(version string, precision int) int {
+	major, minor, _ := versionParts(version)
+	switch {
+	case major > 0 || precision == 1:
+		return 1
+	case minor > 0 || precision == 2:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// nextVersion returns the smallest version that differs from version in its
+// first keep components, e.g. nextVersion("v1.2.3", 2) is "v1.3.0".
+func nextVersion(version string, keep int) string {
+	major, minor, patch := versionParts(version)
+	switch keep {
+	case 1:
+		return fmt.Sprintf("v%d.0.0", major+1)
+	case 2:
+		return fmt.Sprintf("v%d.%d.0", major, minor+1)
+	default:
+		return fmt.Sprintf("v%d.%d.%d", major, minor, patch+1)
+	}
+}
+
+// versionParts returns the numeric components of a canonical version.
+func versionParts(version string) (int, int, int) {
+	core := strings.TrimPrefix(semver.Canonical(version), "v")
+	if i := strings.IndexAny(core, "-+"); i >= 0 {
+		core = core[:i]
+	}
+	var parts [3]int
+	for i, part := range strings.SplitN(core, ".", 3) {
+		parts[i], _ = strconv.Atoi(part)
+	}
+	return parts[0], parts[1], parts[2]
+}
+
+// filterVersions lists the published versions of moduleName that satisfy
+// constraint, in ascending order.
+func filterVersions(ctx context.Context, moduleName, constraint string) FilterVersionsResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[FilterVersionsResult](invalidInput("Module name must not be empty"))
+	}
+
+	c, err := parseConstraint(constraint)
+	if err != nil {
+		return fail[FilterVersionsResult](invalidInput("Invalid constraint %q: %v", constraint, err))
+	}
+
+	data, err := proxyRequest(ctx, escapedListPath(moduleName))
+	if err != nil {
+		if isNotFound(err) {
+			return fail[FilterVersionsResult](fmt.Errorf("Module %s not found on the proxy: %w", moduleName, err))
+		}
+		return fail[FilterVersionsResult](fmt.Errorf("Failed to fetch %s: %w", moduleName, err))
+	}
+
+	// A /vN module only lists vN versions, so a constraint on another major
+	// simply matches nothing.
+	matching := []string{}
+	for _, v := range parseVersionList(data) {
+		if c.matches(v) {
+			matching = append(matching, v)
+		}
+	}
+	sortVersions(matching)
+
+	jsonData, err := json.Marshal(matching)
+	if err != nil {
+		return fail[FilterVersionsResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[FilterVersionsResult](string(jsonData))
+}
@@ -0,0 +1,78 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+func TestParseConstraint(t *testing.T) {
+	tests := []struct {
+		constraint string
+		match      []string
+		noMatch    []string
+	}{
+		{">=1.6.0 <1.9.0", []string{"v1.6.0", "v1.8.9"}, []string{"v1.5.9", "v1.9.0", "v1.7.0-rc.1"}},
+		{">=1.6, <2", []string{"v1.6.0", "v1.99.0"}, []string{"v1.5.0", "v2.0.0"}},
+		{">= 1.6.0", []string{"v1.6.0"}, []string{"v1.5.0"}},
+		{"~1.6.2", []string{"v1.6.2", "v1.6.9"}, []string{"v1.6.1", "v1.7.0"}},
+		{"^1.6.2", []string{"v1.6.2", "v1.9.0"}, []string{"v1.6.1", "v2.0.0"}},
+		{"^0.3.1", []string{"v0.3.1", "v0.3.9"}, []string{"v0.4.0"}},
+		{"=1.6", []string{"v1.6.0", "v1.6.5"}, []string{"v1.7.0"}},
+		{"v1.6.0", []string{"v1.6.0"}, []string{"v1.6.1"}},
+		{">=v2.0.0-rc.1", []string{"v2.0.0-rc.1", "v2.0.0-rc.2", "v2.1.0"}, []string{"v2.0.0-beta.1"}},
+	}
+	for _, tt := range tests {
+		c, err := parseConstraint(tt.constraint)
+		if err != nil {
+			t.Errorf("parseConstraint(%q) failed: %v", tt.constraint, err)
+			continue
+		}
+		for _, v := range tt.match {
+			if !c.matches(v) {
+				t.Errorf("parseConstraint(%q) does not match %s", tt.constraint, v)
+			}
+		}
+		for _, v := range tt.noMatch {
+			if c.matches(v) {
+				t.Errorf("parseConstraint(%q) matches %s", tt.constraint, v)
+			}
+		}
+	}
+
+	for _, constraint := range []string{"", " , ", ">=", ">=x.y", "<1.2.3.4"} {
+		if _, err := parseConstraint(constraint); err == nil {
+			t.Errorf("parseConstraint(%q) succeeded, want an error", constraint)
+		}
+	}
+}
+
+// TestFilterVersionsMajorSuffix checks that a /v2 module, whose list only
+// holds v2 versions, is filtered like any other.
+func TestFilterVersionsMajorSuffix(t *testing.T) {
+	fakeRoutes(t, map[string]string{
+		"proxy.test/example.com/m/v2/@v/list": "v2.1.0\nv2.0.0\nv2.2.0-rc.1\nv2.2.0\n",
+	})
+
+	tests := []struct {
+		constraint string
+		want       string
+	}{
+		{">=2.1", `["v2.1.0","v2.2.0"]`},
+		{"^2.0.0", `["v2.0.0","v2.1.0","v2.2.0"]`},
+		{"<2", `[]`},
+		{">=1.0.0 <2.0.0", `[]`},
+	}
+	for _, tt := range tests {
+		result := filterVersions(context.Background(), "example.com/m/v2", tt.constraint)
+		if result.IsErr() {
+			t.Errorf("filterVersions(%q) failed: %s", tt.constraint, *result.Err())
+			continue
+		}
+		if got := *result.OK(); got != tt.want {
+			t.Errorf("filterVersions(%q) = %s, want %s", tt.constraint, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"go.bytecodealliance.org/cm"
+)
+
+type GetModuleLicenseResult = cm.Result[string, string, string]
+type GetModuleDependentsResult = cm.Result[string, string, string]
+
+// depsDevURL is the base of the deps.dev v3 API.
+const depsDevURL = "https://api.deps.dev/v3"
+
+// depsDevAlphaURL is the base of the deps.dev v3alpha API, which hosts
+// endpoints such as dependents that are not part of v3 yet.
+const depsDevAlphaURL = "https://api.deps.dev/v3alpha"
+
+// depsDevVersionURL is the deps.dev URL of moduleName at version. Unlike the
+// module proxy, deps.dev takes the path as a single URL path element, so
+// slashes are percent-encoded and upper-case letters are left alone rather
+// than written as "!" escapes.
+func depsDevVersionURL(moduleName, version string) string {
+	return depsDevVersionURLAt(depsDevURL, moduleName, version)
+}
+
+// depsDevVersionURLAt is depsDevVersionURL for the API rooted at base.
+func depsDevVersionURLAt(base, moduleName, version string) string {
+	return fmt.Sprintf("%s/systems/GO/packages/%s/versions/%s", base, url.PathEscape(moduleName), url.PathEscape(version))
+}
+
+// depsDevVersion is the subset of a deps.dev GetVersion response used here.
+type depsDevVersion struct {
+	Licenses        []string `json:"licenses"`
+	RelatedProjects []struct {
+		ProjectKey struct {
+			ID string `json:"id"`
+		} `json:"projectKey"`
+		RelationType string `json:"relationType"`
+	} `json:"relatedProjects"`
+}
+
+// sourceRepository is the deps.dev project key, e.g. "github.com/gorilla/mux",
+// of the repository the version was built from, or "" when deps.dev does not
+// link one.
+func (v *depsDevVersion) sourceRepository() string {
+	for _, project := range v.RelatedProjects {
+		if project.RelationType == "SOURCE_REPO" {
+			return project.ProjectKey.ID
+		}
+	}
+	return ""
+}
+
+// fetchDepsDevVersion asks deps.dev about moduleName at version. It returns
+// nil without error when deps.dev has not indexed that version, which is
+// common for newly published modules.
+func fetchDepsDevVersion(ctx context.Context, moduleName, version string) (*depsDevVersion, error) {
+	if err := checkPublic(moduleName); err != nil {
+		return nil, err
+	}
+
+	data, err := httpRequest(ctx, depsDevVersionURL(moduleName, version))
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to query deps.dev for %s@%s: %w", moduleName, version, err)
+	}
+
+	var info depsDevVersion
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse deps.dev response for %s@%s: %w", moduleName, version, err)
+	}
+	return &info, nil
+}
+
+// moduleLicense is the result of getModuleLicense.
+type moduleLicense struct {
+	Module   string   `json:"module"`
+	Version  string   `json:"version"`
+	Indexed  bool     `json:"indexed"`
+	Licenses []string `json:"licenses"`
+}
+
+// getModuleLicense reports the license expressions deps.dev detected for
+// moduleName at version, resolving @latest first when version is empty.
+func getModuleLicense(ctx context.Context, moduleName, version string) GetModuleLicenseResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[GetModuleLicenseResult](invalidInput("Module name must not be empty"))
+	}
+
+	if version == "" {
+		latest, err := resolveLatestVersion(ctx, moduleName)
+		if err != nil {
+			return fail[GetModuleLicenseResult](err)
+		}
+		version = latest
+	}
+
+	info, err := fetchDepsDevVersion(ctx, moduleName, version)
+	if err != nil {
+		return fail[GetModuleLicenseResult](err)
+	}
+
+	result := moduleLicense{Module: moduleName, Version: version, Licenses: []string{}}
+	if info != nil {
+		result.Indexed = true
+		if info.Licenses != nil {
+			result.Licenses = info.Licenses
+		}
+	}
+
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		return fail[GetModuleLicenseResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[GetModuleLicenseResult](string(jsonData))
+}
+
+// moduleDependents is the result of getModuleDependents.
+type moduleDependents struct {
+	Module   string `json:"module"`
+	Version  string `json:"version"`
+	Indexed  bool   `json:"indexed"`
+	Total    int    `json:"total"`
+	Direct   int    `json:"direct"`
+	Indirect int    `json:"indirect"`
+}
+
+// getModuleDependents reports how many packages deps.dev knows to depend on
+// the latest version of moduleName. The deps.dev dependents endpoint
+// returns counts only, with no list of dependents to sample or page
+// through, so the result carries counts alone; a module deps.dev has not
+// indexed reports zeros with indexed unset.
+func getModuleDependents(ctx context.Context, moduleName string) GetModuleDependentsResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[GetModuleDependentsResult](invalidInput("Module name must not be empty"))
+	}
+
+	if err := checkPublic(moduleName); err != nil {
+		return fail[GetModuleDependentsResult](err)
+	}
+
+	version, err := resolveLatestVersion(ctx, moduleName)
+	if err != nil {
+		return fail[GetModuleDependentsResult](err)
+	}
+
+	result := moduleDependents{Module: moduleName, Version: version}
+	data, err := httpRequest(ctx, depsDevVersionURLAt(depsDevAlphaURL, moduleName, version)+":dependents")
+	switch {
+	case err == nil:
+		var counts struct {
+			DependentCount         int `json:"dependentCount"`
+			DirectDependentCount   int `json:"directDependentCount"`
+			IndirectDependentCount int `json:"indirectDependentCount"`
+		}
+		if err := json.Unmarshal(data, &counts); err != nil {
+			return fail[GetModuleDependentsResult](fmt.Errorf("Failed to parse deps.dev response for %s@%s: %w", moduleName, version, err))
+		}
+		result.Indexed = true
+		result.Total = counts.DependentCount
+		result.Direct = counts.DirectDependentCount
+		result.Indirect = counts.IndirectDependentCount
+	case !isNotFound(err):
+		return fail[GetModuleDependentsResult](fmt.Errorf("Failed to query deps.dev for %s@%s: %w", moduleName, version, err))
+	}
+
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		return fail[GetModuleDependentsResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[GetModuleDependentsResult](string(jsonData))
+}
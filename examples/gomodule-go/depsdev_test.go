@@ -0,0 +1,23 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import "testing"
+
+func TestDepsDevVersionURLAt(t *testing.T) {
+	tests := []struct {
+		module  string
+		version string
+		want    string
+	}{
+		{"github.com/gorilla/mux", "v1.8.1", "https://deps.test/v3/systems/GO/packages/github.com%2Fgorilla%2Fmux/versions/v1.8.1"},
+		{"github.com/Azure/azure-sdk-for-go", "v68.0.0+incompatible", "https://deps.test/v3/systems/GO/packages/github.com%2FAzure%2Fazure-sdk-for-go/versions/v68.0.0+incompatible"},
+		{"golang.org/x/mod", "v0.0.0-20240102150405-abcdef123456", "https://deps.test/v3/systems/GO/packages/golang.org%2Fx%2Fmod/versions/v0.0.0-20240102150405-abcdef123456"},
+	}
+	for _, tt := range tests {
+		if got := depsDevVersionURLAt("https://deps.test/v3", tt.module, tt.version); got != tt.want {
+			t.Errorf("depsDevVersionURLAt(%q, %q) = %q, want %q", tt.module, tt.version, got, tt.want)
+		}
+	}
+}
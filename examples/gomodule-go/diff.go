@@ -0,0 +1,375 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.bytecodealliance.org/cm"
+	"golang.org/x/mod/modfile"
+)
+
+type DiffGoModResult = cm.Result[string, string, string]
+type DiffDependenciesResult = cm.Result[string, string, string]
+
+// diffContext is the number of unchanged lines shown around each change.
+const diffContext = 3
+
+// diffOp is one line of an edit script: ' ' keeps, '-' removes and '+'
+// adds a line.
+type diffOp struct {
+	kind byte
+	line string
+}
+
+// diffSummary counts the lines an edit script touches. A removed line
+// directly replaced by an added one counts as changed rather than as both.
+type diffSummary struct {
+	Added   int `json:"added"`
+	Removed int `json:"removed"`
+	Changed int `json:"changed"`
+}
+
+// goModDiff is the result of diffGoMod.
+type goModDiff struct {
+	Module  string      `json:"module"`
+	From    string      `json:"from"`
+	To      string      `json:"to"`
+	Diff    string      `json:"diff"`
+	Summary diffSummary `json:"summary"`
+}
+
+// diffGoMod returns a unified diff between the go.mod files of moduleName at
+// fromVersion and toVersion, fetched concurrently.
+func diffGoMod(ctx context.Context, moduleName, fromVersion, toVersion string) DiffGoModResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[DiffGoModResult](invalidInput("Module name must not be empty"))
+	}
+
+	versions := []string{canonicalVersion(fromVersion), canonicalVersion(toVersion)}
+	for _, version := range versions {
+		if version == "" {
+			return fail[DiffGoModResult](invalidInput("Both versions must be given"))
+		}
+	}
+
+	files := make([][]byte, len(versions))
+	failures := make([]error, len(versions))
+	forEachModule(ctx, versions, func(i int, version string) {
+		files[i], failures[i] = fetchGoMod(ctx, moduleName, version)
+	})
+	for i, label := range []string{"from", "to"} {
+		if failures[i] != nil {
+			return fail[DiffGoModResult](fmt.Errorf("Failed to fetch %s version %s: %w", label, versions[i], failures[i]))
+		}
+	}
+
+	ops := diffLines(splitLines(string(files[0])), splitLines(string(files[1])))
+	result := goModDiff{
+		Module: moduleName,
+		From:   versions[0],
+		To:     versions[1],
+		Diff: unifiedDiff(ops,
+			fmt.Sprintf("%s@%s/go.mod", moduleName, versions[0]),
+			fmt.Sprintf("%s@%s/go.mod", moduleName, versions[1])),
+		Summary: summarizeDiff(ops),
+	}
+
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		return fail[DiffGoModResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[DiffGoModResult](string(jsonData))
+}
+
+// splitLines splits text into lines without their terminators.
+func splitLines(text string) []string {
+	text = strings.TrimSuffix(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+// diffLines computes a minimal edit script turning a into b from their
+// longest common subsequence. go.mod files are small, so the quadratic
+// table is not a concern.
+func diffLines(a, b []string) []diffOp {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []diffOp
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, diffOp{' ', a[i]})
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] > lcs[i+1][j]):
+			ops = append(ops, diffOp{'+', b[j]})
+			j++
+		default:
+			ops = append(ops, diffOp{'-', a[i]})
+			i++
+		}
+	}
+	return ops
+}
+
+// unifiedDiff renders ops as a unified diff with diffContext lines of
+// context, or "" when nothing changed.
+func unifiedDiff(ops []diffOp, fromName, toName string) string {
+	var out strings.Builder
+	for start := 0; start < len(ops); {
+		if ops[start].kind == ' ' {
+			start++
+			continue
+		}
+
+		// Extend the hunk until diffContext*2 unchanged lines separate it
+		// from the next change.
+		end := start
+		for k := start; k < len(ops); k++ {
+			if ops[k].kind != ' ' {
+				end = k + 1
+			} else if k-end >= diffContext*2 {
+				break
+			}
+		}
+		lo := max(start-diffContext, 0)
+		hi := min(end+diffContext, len(ops))
+
+		fromLine, toLine := 1, 1
+		for _, op := range ops[:lo] {
+			if op.kind != '+' {
+				fromLine++
+			}
+			if op.kind != '-' {
+				toLine++
+			}
+		}
+		fromCount, toCount := 0, 0
+		for _, op := range ops[lo:hi] {
+			if op.kind != '+' {
+				fromCount++
+			}
+			if op.kind != '-' {
+				toCount++
+			}
+		}
+
+		if out.Len() == 0 {
+			fmt.Fprintf(&out, "--- %s\n+++ %s\n", fromName, toName)
+		}
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n", hunkRange(fromLine, fromCount), hunkRange(toLine, toCount))
+		for _, op := range ops[lo:hi] {
+			fmt.Fprintf(&out, "%c%s\n", op.kind, op.line)
+		}
+		start = hi
+	}
+	return out.String()
+}
+
+// hunkRange formats a hunk header range; an empty range names the line
+// before it, as diff -u does.
+func hunkRange(line, count int) string {
+	if count == 0 {
+		line--
+	}
+	if count == 1 {
+		return fmt.Sprint(line)
+	}
+	return fmt.Sprintf("%d,%d", line, count)
+}
+
+// summarizeDiff counts the added, removed and changed lines in ops.
+func summarizeDiff(ops []diffOp) diffSummary {
+	var summary diffSummary
+	removed, added := 0, 0
+	flush := func() {
+		changed := min(removed, added)
+		summary.Changed += changed
+		summary.Removed += removed - changed
+		summary.Added += added - changed
+		removed, added = 0, 0
+	}
+	for _, op := range ops {
+		switch op.kind {
+		case '-':
+			removed++
+		case '+':
+			added++
+		default:
+			flush()
+		}
+	}
+	flush()
+	return summary
+}
+
+// requireChange is one requirement that differs between two go.mod files.
+// From is empty for an added requirement and To for a removed one.
+type requireChange struct {
+	Path string `json:"path"`
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+}
+
+// requireChanges groups the requirement changes of one kind, direct or
+// indirect. Each list is empty rather than null.
+type requireChanges struct {
+	Added   []requireChange `json:"added"`
+	Removed []requireChange `json:"removed"`
+	Changed []requireChange `json:"changed"`
+}
+
+// reclassifiedRequire is a requirement kept at the same version whose
+// "// indirect" marking changed; Indirect is the new marking.
+type reclassifiedRequire struct {
+	Path     string `json:"path"`
+	Version  string `json:"version"`
+	Indirect bool   `json:"indirect"`
+}
+
+// dependencyDiff is the result of diffDependencies. A requirement counts as
+// direct when either version requires it directly, so Indirect holds the
+// changes that only touch indirect requirements. Message explains a
+// go.mod the proxy synthesized for a version that predates modules.
+type dependencyDiff struct {
+	Module       string                `json:"module"`
+	From         string                `json:"from"`
+	To           string                `json:"to"`
+	Direct       requireChanges        `json:"direct"`
+	Indirect     requireChanges        `json:"indirect"`
+	Reclassified []reclassifiedRequire `json:"reclassified"`
+	Message      string                `json:"message,omitempty"`
+}
+
+// diffDependencies compares the require directives of the go.mod files of
+// moduleName at fromVersion and toVersion, fetched concurrently.
+func diffDependencies(ctx context.Context, moduleName, fromVersion, toVersion string) DiffDependenciesResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[DiffDependenciesResult](invalidInput("Module name must not be empty"))
+	}
+
+	versions := []string{canonicalVersion(fromVersion), canonicalVersion(toVersion)}
+	for _, version := range versions {
+		if version == "" {
+			return fail[DiffDependenciesResult](invalidInput("Both versions must be given"))
+		}
+	}
+
+	files := make([]*modfile.File, len(versions))
+	failures := make([]error, len(versions))
+	forEachModule(ctx, versions, func(i int, version string) {
+		files[i], _, failures[i] = fetchModFile(ctx, moduleName, version)
+	})
+	for i, label := range []string{"from", "to"} {
+		if failures[i] != nil {
+			return fail[DiffDependenciesResult](fmt.Errorf("Failed to fetch %s version %s: %w", label, versions[i], failures[i]))
+		}
+	}
+
+	result := diffRequires(files[0], files[1])
+	result.Module, result.From, result.To = moduleName, versions[0], versions[1]
+
+	var synthesized []string
+	for i, file := range files {
+		if isSynthesizedGoMod(file) {
+			synthesized = append(synthesized, versions[i])
+		}
+	}
+	if len(synthesized) > 0 {
+		result.Message = fmt.Sprintf("%s@%s has no go.mod of its own; the proxy serves a synthesized one without requirements, so the dependencies of that version are unknown and show up as added or removed",
+			moduleName, strings.Join(synthesized, " and "))
+	}
+
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		return fail[DiffDependenciesResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[DiffDependenciesResult](string(jsonData))
+}
+
+// diffRequires sorts the requirements that differ between from and to into
+// direct and indirect changes, ordered by path.
+func diffRequires(from, to *modfile.File) dependencyDiff {
+	result := dependencyDiff{
+		Direct:       requireChanges{Added: []requireChange{}, Removed: []requireChange{}, Changed: []requireChange{}},
+		Indirect:     requireChanges{Added: []requireChange{}, Removed: []requireChange{}, Changed: []requireChange{}},
+		Reclassified: []reclassifiedRequire{},
+	}
+
+	before := make(map[string]*modfile.Require)
+	for _, req := range from.Require {
+		before[req.Mod.Path] = req
+	}
+	after := make(map[string]*modfile.Require)
+	for _, req := range to.Require {
+		after[req.Mod.Path] = req
+	}
+
+	var paths []string
+	for path := range before {
+		paths = append(paths, path)
+	}
+	for path := range after {
+		if before[path] == nil {
+			paths = append(paths, path)
+		}
+	}
+	slices.Sort(paths)
+
+	for _, path := range paths {
+		old, updated := before[path], after[path]
+		changes := &result.Indirect
+		if (old != nil && !old.Indirect) || (updated != nil && !updated.Indirect) {
+			changes = &result.Direct
+		}
+
+		switch {
+		case old == nil:
+			changes.Added = append(changes.Added, requireChange{Path: path, To: updated.Mod.Version})
+		case updated == nil:
+			changes.Removed = append(changes.Removed, requireChange{Path: path, From: old.Mod.Version})
+		case old.Mod.Version != updated.Mod.Version:
+			changes.Changed = append(changes.Changed, requireChange{Path: path, From: old.Mod.Version, To: updated.Mod.Version})
+		case old.Indirect != updated.Indirect:
+			result.Reclassified = append(result.Reclassified, reclassifiedRequire{Path: path, Version: updated.Mod.Version, Indirect: updated.Indirect})
+		}
+	}
+	return result
+}
+
+// isSynthesizedGoMod reports whether file is the go.mod the proxy makes up
+// for a version without one: a lone module directive.
+func isSynthesizedGoMod(file *modfile.File) bool {
+	for _, stmt := range file.Syntax.Stmt {
+		line, ok := stmt.(*modfile.Line)
+		if !ok || len(line.Token) == 0 || line.Token[0] != "module" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,163 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"archive/zip"
+	"compress/flate"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.bytecodealliance.org/cm"
+	"golang.org/x/mod/modfile"
+)
+
+// toolError is the JSON payload of every failed export. Category is one of
+// the requestError categories ("not_found", "gone", "rate_limited",
+// "client_error", "server_error", "timeout", "cancelled", "network"),
+// "parse_error", "invalid_input" or, for failures that fit none of those,
+// "unknown". Message is the human-readable description; URL and Status
+// are those of the failed request, when there was one.
+type toolError struct {
+	Category string `json:"category"`
+	Message  string `json:"message"`
+	URL      string `json:"url,omitempty"`
+	Status   int    `json:"status,omitempty"`
+}
+
+// inputError is the error of a call rejected for its arguments or for the
+// component's configuration, before anything is fetched.
+type inputError struct {
+	message string
+}
+
+func (e *inputError) Error() string { return e.message }
+
+// invalidInput returns an inputError with a formatted message.
+func invalidInput(format string, args ...interface{}) error {
+	return &inputError{message: fmt.Sprintf(format, args...)}
+}
+
+// classifyError assigns err to a toolError category from the errors it
+// wraps. A requestError decides, so a 404 whose body says "fetch timed
+// out" is still not_found; the sentinels and decoding errors below it only
+// count for failures outside a request.
+func classifyError(err error) string {
+	var reqErr *requestError
+	var statusErr *httpStatusError
+	var privateErr *privateModuleError
+	var inputErr *inputError
+	switch {
+	case errors.As(err, &reqErr):
+		return reqErr.Category
+	case errors.As(err, &statusErr):
+		return statusCategory(statusErr.StatusCode)
+	case errors.Is(err, errCancelled), errors.Is(err, context.Canceled):
+		return "cancelled"
+	case errors.Is(err, errTimedOut), errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, errRateLimit):
+		return "rate_limited"
+	case errors.As(err, &inputErr), errors.As(err, &privateErr), errors.Is(err, errInsecure):
+		return "invalid_input"
+	case isParseError(err):
+		return "parse_error"
+	}
+	return "unknown"
+}
+
+// isParseError reports whether err wraps a failure to decode JSON, a
+// go.mod file, a zip archive or a compressed body.
+func isParseError(err error) bool {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var timeErr *time.ParseError
+	var modErrs modfile.ErrorList
+	var modErr *modfile.Error
+	var flateErr flate.CorruptInputError
+	return errors.As(err, &syntaxErr) || errors.As(err, &typeErr) || errors.As(err, &timeErr) ||
+		errors.As(err, &modErrs) || errors.As(err, &modErr) || errors.As(err, &flateErr) ||
+		errors.Is(err, gzip.ErrHeader) || errors.Is(err, gzip.ErrChecksum) ||
+		errors.Is(err, zip.ErrFormat) || errors.Is(err, zip.ErrAlgorithm) || errors.Is(err, zip.ErrChecksum)
+}
+
+// newToolError describes err as a toolError, taking the URL and status
+// from the request that failed, if any.
+func newToolError(err error) toolError {
+	failure := toolError{Category: classifyError(err), Message: err.Error()}
+	var reqErr *requestError
+	var statusErr *httpStatusError
+	if errors.As(err, &reqErr) {
+		failure.URL = reqErr.URL
+		failure.Status = reqErr.Status
+	} else if errors.As(err, &statusErr) {
+		failure.Status = statusErr.StatusCode
+	}
+	return failure
+}
+
+// fail returns the failed result of a tool for err, carrying its toolError
+// JSON.
+func fail[R cm.AnyResult[Shape, OK, string], Shape, OK any](err error) R {
+	payload, marshalErr := json.Marshal(newToolError(err))
+	if marshalErr != nil {
+		return cm.Err[R](err.Error())
+	}
+	return cm.Err[R](string(payload))
+}
+
+// runTool runs one invocation of the named tool under a context of its own.
+// The context is cancelled once the tool returns, stopping any goroutines it
+// left behind, such as the losers of a proxy race. The wasip2 host cannot
+// cancel an export while it runs yet; should the context be cancelled
+// before fn returns, whatever fn produced is replaced by a cancelled error,
+// since batch helpers stop handing out work once it is.
+func runTool[S, T any](name string, fn func(ctx context.Context) cm.Result[S, T, string]) cm.Result[S, T, string] {
+	countInvocation(name)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	result := fn(ctx)
+	if ctx.Err() != nil {
+		return fail[cm.Result[S, T, string]](fmt.Errorf("%s %w", name, errCancelled))
+	}
+	return result
+}
+
+// tool0 through tool3 wrap the export of the named tool, taking that many
+// parameters after its context, so that its invocations are counted and run
+// under runTool.
+func tool0[S, T any](name string, fn func(context.Context) cm.Result[S, T, string]) func() cm.Result[S, T, string] {
+	return func() cm.Result[S, T, string] {
+		return runTool(name, fn)
+	}
+}
+
+func tool1[A, S, T any](name string, fn func(context.Context, A) cm.Result[S, T, string]) func(A) cm.Result[S, T, string] {
+	return func(a A) cm.Result[S, T, string] {
+		return runTool(name, func(ctx context.Context) cm.Result[S, T, string] {
+			return fn(ctx, a)
+		})
+	}
+}
+
+func tool2[A, B, S, T any](name string, fn func(context.Context, A, B) cm.Result[S, T, string]) func(A, B) cm.Result[S, T, string] {
+	return func(a A, b B) cm.Result[S, T, string] {
+		return runTool(name, func(ctx context.Context) cm.Result[S, T, string] {
+			return fn(ctx, a, b)
+		})
+	}
+}
+
+func tool3[A, B, C, S, T any](name string, fn func(context.Context, A, B, C) cm.Result[S, T, string]) func(A, B, C) cm.Result[S, T, string] {
+	return func(a A, b B, c C) cm.Result[S, T, string] {
+		return runTool(name, func(ctx context.Context) cm.Result[S, T, string] {
+			return fn(ctx, a, b, c)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"go.bytecodealliance.org/cm"
+	"golang.org/x/mod/modfile"
+)
+
+func TestClassifyError(t *testing.T) {
+	notFound := newRequestError("https://proxy.test/x/@latest", &httpStatusError{StatusCode: 404, Body: "fetch timed out"})
+	var syntaxErr error
+	if err := json.Unmarshal([]byte("{"), new(struct{})); err != nil {
+		syntaxErr = fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	_, modErr := modfile.Parse("go.mod", []byte("module"), nil)
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"request", fmt.Errorf("Failed to fetch x: %w", notFound), "not_found"},
+		{"server error", newRequestError("https://proxy.test/x/@v/list", &httpStatusError{StatusCode: 502}), "server_error"},
+		{"request timeout", newRequestError("https://proxy.test/x/@v/list", timeoutError("https://proxy.test/x/@v/list")), "timeout"},
+		{"bare status", &httpStatusError{StatusCode: 429}, "rate_limited"},
+		{"cancelled", fmt.Errorf("get-go-mod %w", errCancelled), "cancelled"},
+		{"context cancelled", context.Canceled, "cancelled"},
+		{"deadline", fmt.Errorf("waiting: %w", context.DeadlineExceeded), "timeout"},
+		{"input", invalidInput("Invalid query %q: expected latest", "x"), "invalid_input"},
+		{"private", &privateModuleError{module: "corp.example/x", variable: "GOPRIVATE"}, "invalid_input"},
+		{"insecure", checkScheme("http://proxy.test"), "invalid_input"},
+		{"json", syntaxErr, "parse_error"},
+		{"go.mod", describe(modErr, "Failed to parse go.mod"), "parse_error"},
+		// Wording alone no longer decides the category.
+		{"wording", errors.New("expected a version, got an invalid one that was not found"), "unknown"},
+	}
+	saved := insecure
+	insecure = false
+	t.Cleanup(func() { insecure = saved })
+	for _, tt := range tests {
+		if got := classifyError(tt.err); got != tt.want {
+			t.Errorf("%s: classifyError(%v) = %q, want %q", tt.name, tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestFail(t *testing.T) {
+	url := "https://proxy.test/x/@v/list"
+	err := fmt.Errorf("Failed to fetch x: %w", newRequestError(url, &httpStatusError{StatusCode: 410}))
+	result := fail[cm.Result[string, string, string]](err)
+	if !result.IsErr() {
+		t.Fatal("fail returned a successful result")
+	}
+
+	var got toolError
+	if err := json.Unmarshal([]byte(*result.Err()), &got); err != nil {
+		t.Fatalf("failed to parse toolError %q: %v", *result.Err(), err)
+	}
+	want := toolError{Category: "gone", Message: err.Error(), URL: url, Status: 410}
+	if got != want {
+		t.Errorf("fail(%v) = %+v, want %+v", err, got, want)
+	}
+}
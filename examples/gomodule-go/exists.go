@@ -0,0 +1,137 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.bytecodealliance.org/cm"
+	"golang.org/x/mod/semver"
+)
+
+type CheckModuleExistsResult = cm.Result[string, string, string]
+
+// moduleExistence is the result of checkModuleExists. Status is "exists",
+// "not_found" (404), "gone" (410: the proxy knows the path but cannot serve
+// it, e.g. because it was removed or is private) or "error".
+type moduleExistence struct {
+	Module  string `json:"module"`
+	Status  string `json:"status"`
+	Version string `json:"version,omitempty"`
+	// Message is the proxy's explanation for 404 and 410 responses, or the
+	// transport failure for errors.
+	Message string `json:"message,omitempty"`
+}
+
+// checkModuleExists fetches @latest for moduleName and reports whether the
+// proxy can serve it.
+func checkModuleExists(ctx context.Context, moduleName string) CheckModuleExistsResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[CheckModuleExistsResult](invalidInput("Module name must not be empty"))
+	}
+
+	result := moduleExistence{Module: moduleName}
+	data, err := proxyRequest(ctx, escapedLatestPath(moduleName))
+
+	var statusErr *httpStatusError
+	switch {
+	case err == nil:
+		var info struct{ Version string }
+		if err := json.Unmarshal(data, &info); err != nil {
+			result.Status = "error"
+			result.Message = fmt.Sprintf("failed to parse JSON: %v", err)
+			break
+		}
+		result.Status = "exists"
+		result.Version = info.Version
+	case errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound:
+		result.Status = "not_found"
+		result.Message = statusErr.Body
+	case errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusGone:
+		result.Status = "gone"
+		result.Message = statusErr.Body
+	default:
+		result.Status = "error"
+		result.Message = err.Error()
+	}
+
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		return fail[CheckModuleExistsResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[CheckModuleExistsResult](string(jsonData))
+}
+
+type VersionExistsResult = cm.Result[string, string, string]
+
+// versionExistence is the result of versionExists. Status is "exists",
+// "not_found" or "gone", as for moduleExistence.
+type versionExistence struct {
+	Module    string `json:"module"`
+	Requested string `json:"requested"`
+	Version   string `json:"version"`
+	Exists    bool   `json:"exists"`
+	Status    string `json:"status"`
+	Time      string `json:"time,omitempty"`
+	Message   string `json:"message,omitempty"`
+}
+
+// versionExists reports whether the proxy serves moduleName at version. The
+// version is canonicalized first, so "1.2", "v1.2.0" and "v1.2.0+meta" all
+// name v1.2.0; "+incompatible" is kept because it is part of the version.
+// A 404 or 410 is a successful answer with Exists false; only an invalid
+// version or a failure to reach the proxy is returned as an error.
+func versionExists(ctx context.Context, moduleName, version string) VersionExistsResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[VersionExistsResult](invalidInput("Module name must not be empty"))
+	}
+
+	requested := strings.TrimSpace(version)
+	version = canonicalVersion(requested)
+	if !semver.IsValid(version) {
+		return fail[VersionExistsResult](invalidInput("Invalid semantic version: %q", requested))
+	}
+	incompatible := semver.Build(version) == "+incompatible"
+	if version = semver.Canonical(version); incompatible {
+		version += "+incompatible"
+	}
+
+	result := versionExistence{Module: moduleName, Requested: requested, Version: version}
+	info, err := fetchVersionInfo(ctx, moduleName, version)
+
+	var statusErr *httpStatusError
+	switch {
+	case err == nil:
+		result.Exists = true
+		result.Status = "exists"
+		result.Version = info.Version
+		if !info.Time.IsZero() {
+			result.Time = info.Time.Format(time.RFC3339)
+		}
+	case errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound:
+		result.Status = "not_found"
+		result.Message = strings.TrimSpace(statusErr.Body)
+	case errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusGone:
+		result.Status = "gone"
+		result.Message = strings.TrimSpace(statusErr.Body)
+	default:
+		return fail[VersionExistsResult](err)
+	}
+
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		return fail[VersionExistsResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[VersionExistsResult](string(jsonData))
+}
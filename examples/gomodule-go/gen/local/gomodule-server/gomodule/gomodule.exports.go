@@ -10,17 +10,487 @@ import (
 var Exports struct {
 	// GetLatestVersions represents the caller-defined, exported function "get-latest-versions".
 	//
-	// Get the latest version of multiple Go modules
-	// Returns JSON string with module -> version mapping
+	// Get the latest version of multiple Go modules, skipping retracted versions unless include-retracted is set
+	// Returns JSON object with a results map of module -> version and an
+	// errors map of module -> reason for the modules that could not be
+	// fetched (empty when all succeeded), an error_categories map giving the
+	// category of each of those errors, plus a retracted map naming any
+	// retracted version that was skipped; fails only when every module does
 	//
-	//	get-latest-versions: func(module-names: string) -> result<string, string>
-	GetLatestVersions func(moduleNames string) (result cm.Result[string, string, string])
+	//	get-latest-versions: func(module-names: string, include-retracted: bool) -> result<string, string>
+	GetLatestVersions func(moduleNames string, includeRetracted bool) (result cm.Result[string, string, string])
 
 	// GetModuleInfo represents the caller-defined, exported function "get-module-info".
 	//
 	// Get detailed information about multiple Go modules
-	// Returns JSON string with module information array
+	// Returns the resolved path, version, time and deprecation notice of each module, with
+	// per-module errors set on the entries that could not be fetched; fails
+	// only when every module does
 	//
-	//	get-module-info: func(module-names: string) -> result<string, string>
-	GetModuleInfo func(moduleNames string) (result cm.Result[string, string, string])
+	//	get-module-info: func(module-names: string) -> result<list<module-version>, string>
+	GetModuleInfo func(moduleNames string) (result cm.Result[cm.List[ModuleVersion], cm.List[ModuleVersion], string])
+
+	// ListAllVersions represents the caller-defined, exported function "list-all-versions".
+	//
+	// List the published versions of multiple Go modules newest first, one page at a time: skipping the offset newest and returning at most limit (50 when 0)
+	// Returns JSON object whose results map each module to the page of versions, the total number of versions, the offset and limit used, and has_more set when older versions follow
+	//
+	//	list-all-versions: func(module-names: string, offset: u32, limit: u32) -> result<string, string>
+	ListAllVersions func(moduleNames string, offset uint32, limit uint32) (result cm.Result[string, string, string])
+
+	// ListModuleVersions represents the caller-defined, exported function "list-module-versions".
+	//
+	// List every published version of a single Go module in semver order
+	// Returns JSON string with a version array, oldest first
+	//
+	//	list-module-versions: func(module-name: string) -> result<string, string>
+	ListModuleVersions func(moduleName string) (result cm.Result[string, string, string])
+
+	// GetGoMod represents the caller-defined, exported function "get-go-mod".
+	//
+	// Get the go.mod file of a Go module at a version (empty for latest)
+	// Returns the raw go.mod text
+	//
+	//	get-go-mod: func(module: string, version: string) -> result<string, string>
+	GetGoMod func(module string, version string) (result cm.Result[string, string, string])
+
+	// GetModuleDependencies represents the caller-defined, exported function "get-module-dependencies".
+	//
+	// Get the direct and indirect requirements from a Go module's go.mod
+	// Returns JSON string with a path/version/indirect array
+	//
+	//	get-module-dependencies: func(module: string, version: string) -> result<string, string>
+	GetModuleDependencies func(module string, version string) (result cm.Result[string, string, string])
+
+	// DetectLatestMajor represents the caller-defined, exported function "detect-latest-major".
+	//
+	// Find the newest major version module path (/v2, /v3, ...) of a Go module
+	// Returns JSON string with the module path, major, latest version and whether the input was already on it
+	//
+	//	detect-latest-major: func(module: string) -> result<string, string>
+	DetectLatestMajor func(module string) (result cm.Result[string, string, string])
+
+	// CompareVersions represents the caller-defined, exported function "compare-versions".
+	//
+	// Compare two versions of a Go module using semantic versioning; an empty version-b or "latest" compares version-a with the latest version
+	// Returns JSON string with both versions, the newer one, the change from version-a to version-b (same, patch, minor, major, prerelease or downgrade), whether it is a breaking major bump and the days between releases
+	//
+	//	compare-versions: func(module: string, version-a: string, version-b: string) -> result<string, string>
+	CompareVersions func(module string, versionA string, versionB string) (result cm.Result[string, string, string])
+
+	// GetModuleChecksums represents the caller-defined, exported function "get-module-checksums".
+	//
+	// Look up the h1: hashes of a Go module version in sum.golang.org
+	// Returns JSON string with the record id, zip hash and go.mod hash
+	//
+	//	get-module-checksums: func(module: string, version: string) -> result<string, string>
+	GetModuleChecksums func(module string, version string) (result cm.Result[string, string, string])
+
+	// GetVersionInfo represents the caller-defined, exported function "get-version-info".
+	//
+	// Get the proxy .info record of a specific Go module version, or of @latest when version is "latest"
+	// Returns JSON string with the Version, Time and Origin fields
+	//
+	//	get-version-info: func(module: string, version: string) -> result<string, string>
+	GetVersionInfo func(module string, version string) (result cm.Result[string, string, string])
+
+	// ResolveRevision represents the caller-defined, exported function "resolve-revision".
+	//
+	// Resolve a commit hash, tag or branch name of a Go module to its version
+	// Returns JSON string with the resolved (pseudo-)version and commit time
+	//
+	//	resolve-revision: func(module: string, rev: string) -> result<string, string>
+	ResolveRevision func(module string, rev string) (result cm.Result[string, string, string])
+
+	// GetRetractions represents the caller-defined, exported function "get-retractions".
+	//
+	// List the retract directives in the latest go.mod of a Go module
+	// Returns JSON string with each retracted range, its rationale and the published versions it covers
+	//
+	//	get-retractions: func(module: string) -> result<string, string>
+	GetRetractions func(module string) (result cm.Result[string, string, string])
+
+	// GetLatestStable represents the caller-defined, exported function "get-latest-stable".
+	//
+	// Get the latest tagged stable release of multiple Go modules, ignoring prereleases and pseudo-versions
+	// Returns JSON object whose results map each module to its version, with an error for modules that have no stable release
+	//
+	//	get-latest-stable: func(module-names: string) -> result<string, string>
+	GetLatestStable func(moduleNames string) (result cm.Result[string, string, string])
+
+	// CheckDeprecation represents the caller-defined, exported function "check-deprecation".
+	//
+	// Check whether a Go module, or the newest major version of its path, is deprecated
+	// Returns JSON string with the deprecated flag and message from the latest go.mod
+	//
+	//	check-deprecation: func(module: string) -> result<string, string>
+	CheckDeprecation func(module string) (result cm.Result[string, string, string])
+
+	// CheckVulnerabilities represents the caller-defined, exported function "check-vulnerabilities".
+	//
+	// Check the latest version of multiple Go modules for known vulnerabilities using OSV.dev
+	// Returns JSON object whose results map each module to its version and vulnerability id/summary array
+	//
+	//	check-vulnerabilities: func(module-names: string) -> result<string, string>
+	CheckVulnerabilities func(moduleNames string) (result cm.Result[string, string, string])
+
+	// GetMinGoVersion represents the caller-defined, exported function "get-min-go-version".
+	//
+	// Get the minimum Go version (go directive) required by the latest version of multiple Go modules
+	// Returns JSON object whose results map each module to its go version
+	//
+	//	get-min-go-version: func(modules: string) -> result<string, string>
+	GetMinGoVersion func(modules string) (result cm.Result[string, string, string])
+
+	// GetToolchainInfo represents the caller-defined, exported function "get-toolchain-info".
+	//
+	// Get the go and toolchain directives from a Go module's go.mod (empty version for latest)
+	// Returns JSON string with the go version, toolchain and whether a toolchain directive is present
+	//
+	//	get-toolchain-info: func(module: string, version: string) -> result<string, string>
+	GetToolchainInfo func(module string, version string) (result cm.Result[string, string, string])
+
+	// VerifyChecksum represents the caller-defined, exported function "verify-checksum".
+	//
+	// Verify the go.mod and zip hashes of a Go module version from the proxy against sum.golang.org
+	// Returns JSON string with the verified flag and the expected vs actual hashes
+	//
+	//	verify-checksum: func(module: string, version: string) -> result<string, string>
+	VerifyChecksum func(module string, version string) (result cm.Result[string, string, string])
+
+	// GetReplaceDirectives represents the caller-defined, exported function "get-replace-directives".
+	//
+	// List the replace directives in the go.mod of a Go module version (latest when version is empty)
+	// Returns JSON array of old and new path/version pairs, with local set for filesystem replacements; empty when the module is safe to require directly
+	//
+	//	get-replace-directives: func(module: string, version: string) -> result<string, string>
+	GetReplaceDirectives func(module string, version string) (result cm.Result[string, string, string])
+
+	// GetModuleOrigin represents the caller-defined, exported function "get-module-origin".
+	//
+	// Get the VCS origin (repository URL, ref and commit hash) of the latest version of a Go module
+	// Returns JSON string with the origin fields, or origin_unavailable set when the proxy has no origin recorded
+	//
+	//	get-module-origin: func(module: string) -> result<string, string>
+	GetModuleOrigin func(module string) (result cm.Result[string, string, string])
+
+	// GetLatestMajor represents the caller-defined, exported function "get-latest-major".
+	//
+	// Get the latest version of Go modules, probing /v2, /v3, ... major version paths when the given path does not exist
+	// Returns JSON object whose results map each input module to the resolved module path and version
+	//
+	//	get-latest-major: func(modules: string) -> result<string, string>
+	GetLatestMajor func(modules string) (result cm.Result[string, string, string])
+
+	// GetModuleAge represents the caller-defined, exported function "get-module-age".
+	//
+	// Get how long ago the latest version of Go modules was published, flagging modules older than max-age-days (365 when 0) as potentially unmaintained
+	// Returns JSON object whose results map each module to its version, raw timestamp, relative age and unmaintained flag
+	//
+	//	get-module-age: func(modules: string, max-age-days: u32) -> result<string, string>
+	GetModuleAge func(modules string, maxAgeDays uint32) (result cm.Result[string, string, string])
+
+	// DiffGoMod represents the caller-defined, exported function "diff-go-mod".
+	//
+	// Diff the go.mod files of two versions of a Go module
+	// Returns JSON string with a unified diff and counts of added, removed and changed lines
+	//
+	//	diff-go-mod: func(module: string, from-version: string, to-version: string) -> result<string, string>
+	DiffGoMod func(module string, fromVersion string, toVersion string) (result cm.Result[string, string, string])
+
+	// FilterVersions represents the caller-defined, exported function "filter-versions".
+	//
+	// List the published versions of a Go module that satisfy a constraint such as ">=1.6.0 <1.9.0", "~1.2" or "^1.2.3"
+	// Returns JSON array of matching versions in ascending order; prereleases only match when the constraint names one
+	//
+	//	filter-versions: func(module: string, constraint: string) -> result<string, string>
+	FilterVersions func(module string, constraint string) (result cm.Result[string, string, string])
+
+	// GetLatestPerMajor represents the caller-defined, exported function "get-latest-per-major".
+	//
+	// Get the latest version of every major version series (v1, v2, ...) of a Go module
+	// Returns JSON object keyed by major version with the module path, latest version and release time of each series
+	//
+	//	get-latest-per-major: func(module: string) -> result<string, string>
+	GetLatestPerMajor func(module string) (result cm.Result[string, string, string])
+
+	// ListDependencies represents the caller-defined, exported function "list-dependencies".
+	//
+	// List the require, replace and exclude directives in the go.mod of a Go module version (latest when version is empty)
+	// Returns JSON string with requires, replaces and excludes arrays, each empty when the directive is absent
+	//
+	//	list-dependencies: func(module: string, version: string) -> result<string, string>
+	ListDependencies func(module string, version string) (result cm.Result[string, string, string])
+
+	// GetRecentModules represents the caller-defined, exported function "get-recent-modules".
+	//
+	// List Go module versions published to index.golang.org since an RFC 3339 time (one hour ago when empty), up to limit records (at most 2000; 0 for the maximum)
+	// Returns JSON string with the records (path, version, timestamp) and a count of skipped malformed lines
+	//
+	//	get-recent-modules: func(since: string, limit: u32) -> result<string, string>
+	GetRecentModules func(since string, limit uint32) (result cm.Result[string, string, string])
+
+	// GetDependencyTree represents the caller-defined, exported function "get-dependency-tree".
+	//
+	// Get the transitive requirement tree of a Go module version (latest when version is empty), walking depth levels (3 when 0, at most 10)
+	// Returns JSON string with the tree of path, version and children, marking nodes cut off by depth, cycles or the fetch limit
+	//
+	//	get-dependency-tree: func(module: string, version: string, depth: u32) -> result<string, string>
+	GetDependencyTree func(module string, version string, depth uint32) (result cm.Result[string, string, string])
+
+	// CheckModuleExists represents the caller-defined, exported function "check-module-exists".
+	//
+	// Check whether the module proxy can serve a Go module, telling unknown paths (404) apart from removed or private ones (410)
+	// Returns JSON string with status exists, not_found, gone or error, plus the latest version or the proxy's explanation
+	//
+	//	check-module-exists: func(module: string) -> result<string, string>
+	CheckModuleExists func(module string) (result cm.Result[string, string, string])
+
+	// GetPackageDetails represents the caller-defined, exported function "get-package-details".
+	//
+	// Get the latest version of Go modules together with the synopsis, license and imported-by count from pkg.go.dev
+	// Returns JSON object whose results map each module to its details; makes a second HTTP request per module, and pkg.go.dev fields are omitted when it has no data
+	//
+	//	get-package-details: func(modules: string) -> result<string, string>
+	GetPackageDetails func(modules string) (result cm.Result[string, string, string])
+
+	// ListModuleFiles represents the caller-defined, exported function "list-module-files".
+	//
+	// List the files in the module zip of a Go module version (latest when version is empty)
+	// Returns JSON string with up to 2000 file paths and sizes, the total file count and a truncated flag
+	//
+	//	list-module-files: func(module: string, version: string) -> result<string, string>
+	ListModuleFiles func(module string, version string) (result cm.Result[string, string, string])
+
+	// GetModuleSize represents the caller-defined, exported function "get-module-size".
+	//
+	// Get the download size of the module zip of a Go module version (latest when version is empty), downloading it only when the proxy sends no Content-Length
+	// Returns JSON string with the size in bytes and as a human-readable string, and whether it was reported by the proxy or measured
+	//
+	//	get-module-size: func(module: string, version: string) -> result<string, string>
+	GetModuleSize func(module string, version string) (result cm.Result[string, string, string])
+
+	// Ping represents the caller-defined, exported function "ping".
+	//
+	// Check that the configured module proxy is reachable by fetching @latest of golang.org/x/text
+	// Returns JSON string with the URL fetched and the observed latency in milliseconds
+	//
+	//	ping: func() -> result<string, string>
+	Ping func() (result cm.Result[string, string, string])
+
+	// GetModuleLicense represents the caller-defined, exported function "get-module-license".
+	//
+	// Get the license expressions deps.dev detected for a Go module version (latest when version is empty)
+	// Returns JSON string with the licenses, or indexed set to false when deps.dev does not know the version
+	//
+	//	get-module-license: func(module: string, version: string) -> result<string, string>
+	GetModuleLicense func(module string, version string) (result cm.Result[string, string, string])
+
+	// GetModuleVulnerabilities represents the caller-defined, exported function "get-module-vulnerabilities".
+	//
+	// Get the OSV advisories affecting a Go module version (latest when version is empty)
+	// Returns JSON string with a vulnerabilities array of id, summary, severity, affected ranges and fixed-in versions; empty when none apply
+	//
+	//	get-module-vulnerabilities: func(module: string, version: string) -> result<string, string>
+	GetModuleVulnerabilities func(module string, version string) (result cm.Result[string, string, string])
+
+	// ScanLicenses represents the caller-defined, exported function "scan-licenses".
+	//
+	// Look up the licenses of the direct dependencies of a Go module version (latest when version is empty) on deps.dev
+	// Returns JSON string grouping dependencies by license and flagging unknown licenses and licenses missing from the allowlist
+	//
+	//	scan-licenses: func(module: string, version: string) -> result<string, string>
+	ScanLicenses func(module string, version string) (result cm.Result[string, string, string])
+
+	// GetModuleDependents represents the caller-defined, exported function "get-module-dependents".
+	//
+	// Get how many packages depend on the latest version of a Go module, according to deps.dev, which publishes counts only, so the dependents themselves are not listed
+	// Returns JSON string with the total, direct and indirect dependent counts and no sample of dependents; zeros when there are none
+	//
+	//	get-module-dependents: func(module: string) -> result<string, string>
+	GetModuleDependents func(module string) (result cm.Result[string, string, string])
+
+	// GetScorecard represents the caller-defined, exported function "get-scorecard".
+	//
+	// Get the OpenSSF Scorecard deps.dev holds for the source repository of the latest version of each Go module (comma-separated); slower than proxy-only tools because deps.dev responses are large
+	// Returns JSON object whose results map each module to the overall score and per-check scores, or available set to false with a message when deps.dev has not indexed the module or has no scorecard
+	//
+	//	get-scorecard: func(module-names: string) -> result<string, string>
+	GetScorecard func(moduleNames string) (result cm.Result[string, string, string])
+
+	// GetRepositoryScorecard represents the caller-defined, exported function "get-repository-scorecard".
+	//
+	// Get the OpenSSF Scorecard api.securityscorecards.dev publishes for the source repository of a Go module, found from its VCS origin or go-import meta tag
+	// Returns JSON string with the repository, overall score and per-check scores, or available set to false with "no scorecard available"
+	//
+	//	get-repository-scorecard: func(module: string) -> result<string, string>
+	GetRepositoryScorecard func(module string) (result cm.Result[string, string, string])
+
+	// GetPublishTimeline represents the caller-defined, exported function "get-publish-timeline".
+	//
+	// List every published version of a Go module with its publish time, oldest first, keeping the limit most recent (all when 0); with a limit, only the highest versions are looked up
+	// Returns JSON array of version and time, with an error instead of the time for versions whose record could not be fetched
+	//
+	//	get-publish-timeline: func(module: string, limit: u32) -> result<string, string>
+	GetPublishTimeline func(module string, limit uint32) (result cm.Result[string, string, string])
+
+	// GetReleaseSummary represents the caller-defined, exported function "get-release-summary".
+	//
+	// Summarize the release history of Go modules: how many versions were published, and the first and latest version with their dates
+	// Returns JSON object whose results map each module to its version count, first version and date, and latest version and date
+	//
+	//	get-release-summary: func(modules: string) -> result<string, string>
+	GetReleaseSummary func(modules string) (result cm.Result[string, string, string])
+
+	// NormalizeModulePath represents the caller-defined, exported function "normalize-module-path".
+	//
+	// Normalize a Go module path the way the other tools interpret their input, without contacting the proxy
+	// Returns JSON string with the canonical path, the case-encoded proxy path and the normalizations applied (default host, trailing slash, .git suffix, case)
+	//
+	//	normalize-module-path: func(input: string) -> result<string, string>
+	NormalizeModulePath func(input string) (result cm.Result[string, string, string])
+
+	// ValidateModulePath represents the caller-defined, exported function "validate-module-path".
+	//
+	// Validate a Go module path against the module path rules, exactly as given and without contacting the proxy
+	// Returns JSON string with a valid flag, every violated rule (characters, slashes, dots, host, major version suffix, Windows reserved names) and the case-encoded proxy path of valid paths
+	//
+	//	validate-module-path: func(path: string) -> result<string, string>
+	ValidateModulePath func(path string) (result cm.Result[string, string, string])
+
+	// ResolvePackageToModule represents the caller-defined, exported function "resolve-package-to-module".
+	//
+	// Find the Go module that provides an import path by probing its prefixes on the proxy, longest first
+	// Returns JSON string with the module path, its latest version, the package path relative to the module root and the prefixes tried
+	//
+	//	resolve-package-to-module: func(import-path: string) -> result<string, string>
+	ResolvePackageToModule func(importPath string) (result cm.Result[string, string, string])
+
+	// GetModuleReadme represents the caller-defined, exported function "get-module-readme".
+	//
+	// Get the README at the root of a Go module version (latest when version is empty) from its module zip
+	// Returns JSON string with the file name and its content, cut off at 64 KiB with a truncation marker, or found set to false when the module has no README
+	//
+	//	get-module-readme: func(module: string, version: string) -> result<string, string>
+	GetModuleReadme func(module string, version string) (result cm.Result[string, string, string])
+
+	// CheckUpgrade represents the caller-defined, exported function "check-upgrade".
+	//
+	// Check which upgrades are available for a pinned Go module, given as module@version
+	// Returns JSON string with the newest patch of the same minor, the newest minor of the same major (each with already-latest set when the pin is newest), the newer major version module path if any, and the nearest tag of pseudo-version pins
+	//
+	//	check-upgrade: func(spec: string) -> result<string, string>
+	CheckUpgrade func(spec string) (result cm.Result[string, string, string])
+
+	// GetLatestPatch represents the caller-defined, exported function "get-latest-patch".
+	//
+	// Get the newest patch release of a major.minor line (like v1.4) of a Go module, skipping prereleases unless include-prerelease is set
+	// Returns JSON string with found and the version, or a message listing the major.minor lines the module does have
+	//
+	//	get-latest-patch: func(module: string, major-minor: string, include-prerelease: bool) -> result<string, string>
+	GetLatestPatch func(module string, majorMinor string, includePrerelease bool) (result cm.Result[string, string, string])
+
+	// GetReleaseNotes represents the caller-defined, exported function "get-release-notes".
+	//
+	// Get the GitHub release notes of a Go module version (latest when version is empty)
+	// Returns JSON string with the release name, URL, publish time and markdown body, or available set to false when the module is not on GitHub or the version has no release
+	//
+	//	get-release-notes: func(module: string, version: string) -> result<string, string>
+	GetReleaseNotes func(module string, version string) (result cm.Result[string, string, string])
+
+	// AuditGoMod represents the caller-defined, exported function "audit-go-mod".
+	//
+	// Compare the requirements of a pasted go.mod file with their latest versions, skipping indirect requirements unless include-indirect is set
+	// Returns JSON string with the requirements sorted by path, each with its current and latest version, the bump kind and a status of up_to_date, outdated, unresolvable or error
+	//
+	//	audit-go-mod: func(go-mod-contents: string, include-indirect: bool) -> result<string, string>
+	AuditGoMod func(goModContents string, includeIndirect bool) (result cm.Result[string, string, string])
+
+	// VerifyGoSum represents the caller-defined, exported function "verify-go-sum".
+	//
+	// Check the lines of a pasted go.sum file against the Go checksum database
+	// Returns JSON string with a status per line (match, mismatch, not_found, skipped, invalid or error), the number of mismatches and a warning when any hash does not match
+	//
+	//	verify-go-sum: func(go-sum-contents: string) -> result<string, string>
+	VerifyGoSum func(goSumContents string) (result cm.Result[string, string, string])
+
+	// GetDependencyGraph represents the caller-defined, exported function "get-dependency-graph".
+	//
+	// Build the dependency graph of a Go module version (latest when version is empty) up to max-depth levels (3 when 0) and at most 500 module versions, selecting versions by minimal version selection
+	// Returns JSON string with an adjacency list keyed by module@version and the build list of selected dependency versions
+	//
+	//	get-dependency-graph: func(module: string, version: string, max-depth: u32) -> result<string, string>
+	GetDependencyGraph func(module string, version string, maxDepth uint32) (result cm.Result[string, string, string])
+
+	// DecodePseudoVersion represents the caller-defined, exported function "decode-pseudo-version".
+	//
+	// Decode a Go pseudo-version into its base version, commit time and revision, without contacting the proxy
+	// Returns JSON string with the form (no_base, prerelease_base or release_base), base version, RFC 3339 commit time and 12-character revision, or is_pseudo set to false for other versions
+	//
+	//	decode-pseudo-version: func(version: string) -> result<string, string>
+	DecodePseudoVersion func(version string) (result cm.Result[string, string, string])
+
+	// GetLatestVersionsIncludingPrerelease represents the caller-defined, exported function "get-latest-versions-including-prerelease".
+	//
+	// Get the latest stable version of multiple Go modules together with any newer prerelease, which the latest-version lookup hides
+	// Returns JSON object whose results map each module to latest, latest_prerelease (when newer) and whether latest is a stable release; modules without one report what @latest resolves to
+	//
+	//	get-latest-versions-including-prerelease: func(module-names: string) -> result<string, string>
+	GetLatestVersionsIncludingPrerelease func(moduleNames string) (result cm.Result[string, string, string])
+
+	// VersionExists represents the caller-defined, exported function "version-exists".
+	//
+	// Check whether a version of a Go module exists on the proxy, accepting versions with or without the leading v and build metadata
+	// Returns JSON string with exists, the canonical version, and a status of exists, not_found or gone with the proxy's explanation; a missing version is not an error, only an invalid version or an unreachable proxy is
+	//
+	//	version-exists: func(module: string, version: string) -> result<string, string>
+	VersionExists func(module string, version string) (result cm.Result[string, string, string])
+
+	// GetIncompatibleVersions represents the caller-defined, exported function "get-incompatible-versions".
+	//
+	// List the +incompatible versions of a Go module separately from its module-aware versions and check for a /vN major version module path
+	// Returns JSON string with both version lists, the latest of each, any major version path found, and a suggest field of "module has proper /vN path", "use +incompatible" or "none"
+	//
+	//	get-incompatible-versions: func(module: string) -> result<string, string>
+	GetIncompatibleVersions func(module string) (result cm.Result[string, string, string])
+
+	// GetGoVersion represents the caller-defined, exported function "get-go-version".
+	//
+	// Get the go and toolchain directives of the latest go.mod of multiple Go modules
+	// Returns JSON object whose results map each module to version, go version ("unspecified" when absent) and toolchain
+	//
+	//	get-go-version: func(module-names: string) -> result<string, string>
+	GetGoVersion func(moduleNames string) (result cm.Result[string, string, string])
+
+	// SuggestNextVersion represents the caller-defined, exported function "suggest-next-version".
+	//
+	// Suggest the next version to tag for a Go module for a major, minor or patch bump, or all three when bump is empty
+	// Returns JSON string with the highest stable tag and, per bump, the next version and the module path it must be published under
+	//
+	//	suggest-next-version: func(module: string, bump: string) -> result<string, string>
+	SuggestNextVersion func(module string, bump string) (result cm.Result[string, string, string])
+
+	// GetMetrics represents the caller-defined, exported function "get-metrics".
+	//
+	// Get the request, cache hit, miss and eviction, retry, HTTP status code and per-tool invocation counters accumulated since the component was instantiated, and the number of cached responses
+	// Returns JSON string with the counters
+	//
+	//	get-metrics: func() -> result<string, string>
+	GetMetrics func() (result cm.Result[string, string, string])
+
+	// DiffDependencies represents the caller-defined, exported function "diff-dependencies".
+	//
+	// Compare the require directives in the go.mod files of two versions of a Go module, telling direct from indirect-only changes
+	// Returns JSON string with added, removed and changed requirements for direct and indirect dependencies, requirements whose indirect marking changed, and a message when a version predates go.mod
+	//
+	//	diff-dependencies: func(module: string, from-version: string, to-version: string) -> result<string, string>
+	DiffDependencies func(module string, fromVersion string, toVersion string) (result cm.Result[string, string, string])
+
+	// ResolveQuery represents the caller-defined, exported function "resolve-query".
+	//
+	// Resolve a version query to a single version of a Go module as "go get module@query" does: latest (when empty), upgrade, a prefix like v1 or v1.2, a comparison like >=v1.2.0, a version, or a branch, tag or commit
+	// Returns JSON string with the resolved version and its time, how the query was read, and found false with a message when nothing matches
+	//
+	//	resolve-query: func(module: string, query: string) -> result<string, string>
+	ResolveQuery func(module string, query string) (result cm.Result[string, string, string])
 }
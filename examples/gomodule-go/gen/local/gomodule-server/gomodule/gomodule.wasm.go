@@ -10,18 +10,577 @@ import (
 
 //go:wasmexport local:gomodule-server/gomodule#get-latest-versions
 //export local:gomodule-server/gomodule#get-latest-versions
-func wasmexport_GetLatestVersions(moduleNames0 *uint8, moduleNames1 uint32) (result *cm.Result[string, string, string]) {
+func wasmexport_GetLatestVersions(moduleNames0 *uint8, moduleNames1 uint32, includeRetracted0 uint32) (result *cm.Result[string, string, string]) {
 	moduleNames := cm.LiftString[string]((*uint8)(moduleNames0), (uint32)(moduleNames1))
-	result_ := Exports.GetLatestVersions(moduleNames)
+	includeRetracted := (bool)(cm.U32ToBool((uint32)(includeRetracted0)))
+	result_ := Exports.GetLatestVersions(moduleNames, includeRetracted)
 	result = &result_
 	return
 }
 
 //go:wasmexport local:gomodule-server/gomodule#get-module-info
 //export local:gomodule-server/gomodule#get-module-info
-func wasmexport_GetModuleInfo(moduleNames0 *uint8, moduleNames1 uint32) (result *cm.Result[string, string, string]) {
+func wasmexport_GetModuleInfo(moduleNames0 *uint8, moduleNames1 uint32) (result *cm.Result[cm.List[ModuleVersion], cm.List[ModuleVersion], string]) {
 	moduleNames := cm.LiftString[string]((*uint8)(moduleNames0), (uint32)(moduleNames1))
 	result_ := Exports.GetModuleInfo(moduleNames)
 	result = &result_
 	return
 }
+
+//go:wasmexport local:gomodule-server/gomodule#list-all-versions
+//export local:gomodule-server/gomodule#list-all-versions
+func wasmexport_ListAllVersions(moduleNames0 *uint8, moduleNames1 uint32, offset0 uint32, limit0 uint32) (result *cm.Result[string, string, string]) {
+	moduleNames := cm.LiftString[string]((*uint8)(moduleNames0), (uint32)(moduleNames1))
+	offset := (uint32)((uint32)(offset0))
+	limit := (uint32)((uint32)(limit0))
+	result_ := Exports.ListAllVersions(moduleNames, offset, limit)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#list-module-versions
+//export local:gomodule-server/gomodule#list-module-versions
+func wasmexport_ListModuleVersions(moduleName0 *uint8, moduleName1 uint32) (result *cm.Result[string, string, string]) {
+	moduleName := cm.LiftString[string]((*uint8)(moduleName0), (uint32)(moduleName1))
+	result_ := Exports.ListModuleVersions(moduleName)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#get-go-mod
+//export local:gomodule-server/gomodule#get-go-mod
+func wasmexport_GetGoMod(module0 *uint8, module1 uint32, version0 *uint8, version1 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	version := cm.LiftString[string]((*uint8)(version0), (uint32)(version1))
+	result_ := Exports.GetGoMod(module, version)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#get-module-dependencies
+//export local:gomodule-server/gomodule#get-module-dependencies
+func wasmexport_GetModuleDependencies(module0 *uint8, module1 uint32, version0 *uint8, version1 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	version := cm.LiftString[string]((*uint8)(version0), (uint32)(version1))
+	result_ := Exports.GetModuleDependencies(module, version)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#detect-latest-major
+//export local:gomodule-server/gomodule#detect-latest-major
+func wasmexport_DetectLatestMajor(module0 *uint8, module1 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	result_ := Exports.DetectLatestMajor(module)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#compare-versions
+//export local:gomodule-server/gomodule#compare-versions
+func wasmexport_CompareVersions(module0 *uint8, module1 uint32, versionA0 *uint8, versionA1 uint32, versionB0 *uint8, versionB1 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	versionA := cm.LiftString[string]((*uint8)(versionA0), (uint32)(versionA1))
+	versionB := cm.LiftString[string]((*uint8)(versionB0), (uint32)(versionB1))
+	result_ := Exports.CompareVersions(module, versionA, versionB)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#get-module-checksums
+//export local:gomodule-server/gomodule#get-module-checksums
+func wasmexport_GetModuleChecksums(module0 *uint8, module1 uint32, version0 *uint8, version1 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	version := cm.LiftString[string]((*uint8)(version0), (uint32)(version1))
+	result_ := Exports.GetModuleChecksums(module, version)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#get-version-info
+//export local:gomodule-server/gomodule#get-version-info
+func wasmexport_GetVersionInfo(module0 *uint8, module1 uint32, version0 *uint8, version1 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	version := cm.LiftString[string]((*uint8)(version0), (uint32)(version1))
+	result_ := Exports.GetVersionInfo(module, version)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#resolve-revision
+//export local:gomodule-server/gomodule#resolve-revision
+func wasmexport_ResolveRevision(module0 *uint8, module1 uint32, rev0 *uint8, rev1 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	rev := cm.LiftString[string]((*uint8)(rev0), (uint32)(rev1))
+	result_ := Exports.ResolveRevision(module, rev)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#get-retractions
+//export local:gomodule-server/gomodule#get-retractions
+func wasmexport_GetRetractions(module0 *uint8, module1 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	result_ := Exports.GetRetractions(module)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#get-latest-stable
+//export local:gomodule-server/gomodule#get-latest-stable
+func wasmexport_GetLatestStable(moduleNames0 *uint8, moduleNames1 uint32) (result *cm.Result[string, string, string]) {
+	moduleNames := cm.LiftString[string]((*uint8)(moduleNames0), (uint32)(moduleNames1))
+	result_ := Exports.GetLatestStable(moduleNames)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#check-deprecation
+//export local:gomodule-server/gomodule#check-deprecation
+func wasmexport_CheckDeprecation(module0 *uint8, module1 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	result_ := Exports.CheckDeprecation(module)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#check-vulnerabilities
+//export local:gomodule-server/gomodule#check-vulnerabilities
+func wasmexport_CheckVulnerabilities(moduleNames0 *uint8, moduleNames1 uint32) (result *cm.Result[string, string, string]) {
+	moduleNames := cm.LiftString[string]((*uint8)(moduleNames0), (uint32)(moduleNames1))
+	result_ := Exports.CheckVulnerabilities(moduleNames)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#get-min-go-version
+//export local:gomodule-server/gomodule#get-min-go-version
+func wasmexport_GetMinGoVersion(modules0 *uint8, modules1 uint32) (result *cm.Result[string, string, string]) {
+	modules := cm.LiftString[string]((*uint8)(modules0), (uint32)(modules1))
+	result_ := Exports.GetMinGoVersion(modules)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#get-toolchain-info
+//export local:gomodule-server/gomodule#get-toolchain-info
+func wasmexport_GetToolchainInfo(module0 *uint8, module1 uint32, version0 *uint8, version1 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	version := cm.LiftString[string]((*uint8)(version0), (uint32)(version1))
+	result_ := Exports.GetToolchainInfo(module, version)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#verify-checksum
+//export local:gomodule-server/gomodule#verify-checksum
+func wasmexport_VerifyChecksum(module0 *uint8, module1 uint32, version0 *uint8, version1 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	version := cm.LiftString[string]((*uint8)(version0), (uint32)(version1))
+	result_ := Exports.VerifyChecksum(module, version)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#get-replace-directives
+//export local:gomodule-server/gomodule#get-replace-directives
+func wasmexport_GetReplaceDirectives(module0 *uint8, module1 uint32, version0 *uint8, version1 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	version := cm.LiftString[string]((*uint8)(version0), (uint32)(version1))
+	result_ := Exports.GetReplaceDirectives(module, version)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#get-module-origin
+//export local:gomodule-server/gomodule#get-module-origin
+func wasmexport_GetModuleOrigin(module0 *uint8, module1 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	result_ := Exports.GetModuleOrigin(module)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#get-latest-major
+//export local:gomodule-server/gomodule#get-latest-major
+func wasmexport_GetLatestMajor(modules0 *uint8, modules1 uint32) (result *cm.Result[string, string, string]) {
+	modules := cm.LiftString[string]((*uint8)(modules0), (uint32)(modules1))
+	result_ := Exports.GetLatestMajor(modules)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#get-module-age
+//export local:gomodule-server/gomodule#get-module-age
+func wasmexport_GetModuleAge(modules0 *uint8, modules1 uint32, maxAgeDays0 uint32) (result *cm.Result[string, string, string]) {
+	modules := cm.LiftString[string]((*uint8)(modules0), (uint32)(modules1))
+	maxAgeDays := (uint32)((uint32)(maxAgeDays0))
+	result_ := Exports.GetModuleAge(modules, maxAgeDays)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#diff-go-mod
+//export local:gomodule-server/gomodule#diff-go-mod
+func wasmexport_DiffGoMod(module0 *uint8, module1 uint32, fromVersion0 *uint8, fromVersion1 uint32, toVersion0 *uint8, toVersion1 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	fromVersion := cm.LiftString[string]((*uint8)(fromVersion0), (uint32)(fromVersion1))
+	toVersion := cm.LiftString[string]((*uint8)(toVersion0), (uint32)(toVersion1))
+	result_ := Exports.DiffGoMod(module, fromVersion, toVersion)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#filter-versions
+//export local:gomodule-server/gomodule#filter-versions
+func wasmexport_FilterVersions(module0 *uint8, module1 uint32, constraint0 *uint8, constraint1 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	constraint := cm.LiftString[string]((*uint8)(constraint0), (uint32)(constraint1))
+	result_ := Exports.FilterVersions(module, constraint)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#get-latest-per-major
+//export local:gomodule-server/gomodule#get-latest-per-major
+func wasmexport_GetLatestPerMajor(module0 *uint8, module1 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	result_ := Exports.GetLatestPerMajor(module)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#list-dependencies
+//export local:gomodule-server/gomodule#list-dependencies
+func wasmexport_ListDependencies(module0 *uint8, module1 uint32, version0 *uint8, version1 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	version := cm.LiftString[string]((*uint8)(version0), (uint32)(version1))
+	result_ := Exports.ListDependencies(module, version)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#get-recent-modules
+//export local:gomodule-server/gomodule#get-recent-modules
+func wasmexport_GetRecentModules(since0 *uint8, since1 uint32, limit0 uint32) (result *cm.Result[string, string, string]) {
+	since := cm.LiftString[string]((*uint8)(since0), (uint32)(since1))
+	limit := (uint32)((uint32)(limit0))
+	result_ := Exports.GetRecentModules(since, limit)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#get-dependency-tree
+//export local:gomodule-server/gomodule#get-dependency-tree
+func wasmexport_GetDependencyTree(module0 *uint8, module1 uint32, version0 *uint8, version1 uint32, depth0 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	version := cm.LiftString[string]((*uint8)(version0), (uint32)(version1))
+	depth := (uint32)((uint32)(depth0))
+	result_ := Exports.GetDependencyTree(module, version, depth)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#check-module-exists
+//export local:gomodule-server/gomodule#check-module-exists
+func wasmexport_CheckModuleExists(module0 *uint8, module1 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	result_ := Exports.CheckModuleExists(module)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#get-package-details
+//export local:gomodule-server/gomodule#get-package-details
+func wasmexport_GetPackageDetails(modules0 *uint8, modules1 uint32) (result *cm.Result[string, string, string]) {
+	modules := cm.LiftString[string]((*uint8)(modules0), (uint32)(modules1))
+	result_ := Exports.GetPackageDetails(modules)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#list-module-files
+//export local:gomodule-server/gomodule#list-module-files
+func wasmexport_ListModuleFiles(module0 *uint8, module1 uint32, version0 *uint8, version1 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	version := cm.LiftString[string]((*uint8)(version0), (uint32)(version1))
+	result_ := Exports.ListModuleFiles(module, version)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#get-module-size
+//export local:gomodule-server/gomodule#get-module-size
+func wasmexport_GetModuleSize(module0 *uint8, module1 uint32, version0 *uint8, version1 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	version := cm.LiftString[string]((*uint8)(version0), (uint32)(version1))
+	result_ := Exports.GetModuleSize(module, version)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#ping
+//export local:gomodule-server/gomodule#ping
+func wasmexport_Ping() (result *cm.Result[string, string, string]) {
+	result_ := Exports.Ping()
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#get-module-license
+//export local:gomodule-server/gomodule#get-module-license
+func wasmexport_GetModuleLicense(module0 *uint8, module1 uint32, version0 *uint8, version1 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	version := cm.LiftString[string]((*uint8)(version0), (uint32)(version1))
+	result_ := Exports.GetModuleLicense(module, version)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#get-module-vulnerabilities
+//export local:gomodule-server/gomodule#get-module-vulnerabilities
+func wasmexport_GetModuleVulnerabilities(module0 *uint8, module1 uint32, version0 *uint8, version1 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	version := cm.LiftString[string]((*uint8)(version0), (uint32)(version1))
+	result_ := Exports.GetModuleVulnerabilities(module, version)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#scan-licenses
+//export local:gomodule-server/gomodule#scan-licenses
+func wasmexport_ScanLicenses(module0 *uint8, module1 uint32, version0 *uint8, version1 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	version := cm.LiftString[string]((*uint8)(version0), (uint32)(version1))
+	result_ := Exports.ScanLicenses(module, version)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#get-module-dependents
+//export local:gomodule-server/gomodule#get-module-dependents
+func wasmexport_GetModuleDependents(module0 *uint8, module1 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	result_ := Exports.GetModuleDependents(module)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#get-scorecard
+//export local:gomodule-server/gomodule#get-scorecard
+func wasmexport_GetScorecard(moduleNames0 *uint8, moduleNames1 uint32) (result *cm.Result[string, string, string]) {
+	moduleNames := cm.LiftString[string]((*uint8)(moduleNames0), (uint32)(moduleNames1))
+	result_ := Exports.GetScorecard(moduleNames)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#get-repository-scorecard
+//export local:gomodule-server/gomodule#get-repository-scorecard
+func wasmexport_GetRepositoryScorecard(module0 *uint8, module1 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	result_ := Exports.GetRepositoryScorecard(module)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#get-publish-timeline
+//export local:gomodule-server/gomodule#get-publish-timeline
+func wasmexport_GetPublishTimeline(module0 *uint8, module1 uint32, limit0 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	limit := (uint32)((uint32)(limit0))
+	result_ := Exports.GetPublishTimeline(module, limit)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#get-release-summary
+//export local:gomodule-server/gomodule#get-release-summary
+func wasmexport_GetReleaseSummary(modules0 *uint8, modules1 uint32) (result *cm.Result[string, string, string]) {
+	modules := cm.LiftString[string]((*uint8)(modules0), (uint32)(modules1))
+	result_ := Exports.GetReleaseSummary(modules)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#normalize-module-path
+//export local:gomodule-server/gomodule#normalize-module-path
+func wasmexport_NormalizeModulePath(input0 *uint8, input1 uint32) (result *cm.Result[string, string, string]) {
+	input := cm.LiftString[string]((*uint8)(input0), (uint32)(input1))
+	result_ := Exports.NormalizeModulePath(input)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#validate-module-path
+//export local:gomodule-server/gomodule#validate-module-path
+func wasmexport_ValidateModulePath(path0 *uint8, path1 uint32) (result *cm.Result[string, string, string]) {
+	path := cm.LiftString[string]((*uint8)(path0), (uint32)(path1))
+	result_ := Exports.ValidateModulePath(path)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#resolve-package-to-module
+//export local:gomodule-server/gomodule#resolve-package-to-module
+func wasmexport_ResolvePackageToModule(importPath0 *uint8, importPath1 uint32) (result *cm.Result[string, string, string]) {
+	importPath := cm.LiftString[string]((*uint8)(importPath0), (uint32)(importPath1))
+	result_ := Exports.ResolvePackageToModule(importPath)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#get-module-readme
+//export local:gomodule-server/gomodule#get-module-readme
+func wasmexport_GetModuleReadme(module0 *uint8, module1 uint32, version0 *uint8, version1 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	version := cm.LiftString[string]((*uint8)(version0), (uint32)(version1))
+	result_ := Exports.GetModuleReadme(module, version)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#check-upgrade
+//export local:gomodule-server/gomodule#check-upgrade
+func wasmexport_CheckUpgrade(spec0 *uint8, spec1 uint32) (result *cm.Result[string, string, string]) {
+	spec := cm.LiftString[string]((*uint8)(spec0), (uint32)(spec1))
+	result_ := Exports.CheckUpgrade(spec)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#get-latest-patch
+//export local:gomodule-server/gomodule#get-latest-patch
+func wasmexport_GetLatestPatch(module0 *uint8, module1 uint32, majorMinor0 *uint8, majorMinor1 uint32, includePrerelease0 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	majorMinor := cm.LiftString[string]((*uint8)(majorMinor0), (uint32)(majorMinor1))
+	includePrerelease := (bool)(cm.U32ToBool((uint32)(includePrerelease0)))
+	result_ := Exports.GetLatestPatch(module, majorMinor, includePrerelease)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#get-release-notes
+//export local:gomodule-server/gomodule#get-release-notes
+func wasmexport_GetReleaseNotes(module0 *uint8, module1 uint32, version0 *uint8, version1 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	version := cm.LiftString[string]((*uint8)(version0), (uint32)(version1))
+	result_ := Exports.GetReleaseNotes(module, version)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#audit-go-mod
+//export local:gomodule-server/gomodule#audit-go-mod
+func wasmexport_AuditGoMod(goModContents0 *uint8, goModContents1 uint32, includeIndirect0 uint32) (result *cm.Result[string, string, string]) {
+	goModContents := cm.LiftString[string]((*uint8)(goModContents0), (uint32)(goModContents1))
+	includeIndirect := (bool)(cm.U32ToBool((uint32)(includeIndirect0)))
+	result_ := Exports.AuditGoMod(goModContents, includeIndirect)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#verify-go-sum
+//export local:gomodule-server/gomodule#verify-go-sum
+func wasmexport_VerifyGoSum(goSumContents0 *uint8, goSumContents1 uint32) (result *cm.Result[string, string, string]) {
+	goSumContents := cm.LiftString[string]((*uint8)(goSumContents0), (uint32)(goSumContents1))
+	result_ := Exports.VerifyGoSum(goSumContents)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#get-dependency-graph
+//export local:gomodule-server/gomodule#get-dependency-graph
+func wasmexport_GetDependencyGraph(module0 *uint8, module1 uint32, version0 *uint8, version1 uint32, maxDepth0 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	version := cm.LiftString[string]((*uint8)(version0), (uint32)(version1))
+	maxDepth := (uint32)((uint32)(maxDepth0))
+	result_ := Exports.GetDependencyGraph(module, version, maxDepth)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#decode-pseudo-version
+//export local:gomodule-server/gomodule#decode-pseudo-version
+func wasmexport_DecodePseudoVersion(version0 *uint8, version1 uint32) (result *cm.Result[string, string, string]) {
+	version := cm.LiftString[string]((*uint8)(version0), (uint32)(version1))
+	result_ := Exports.DecodePseudoVersion(version)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#get-latest-versions-including-prerelease
+//export local:gomodule-server/gomodule#get-latest-versions-including-prerelease
+func wasmexport_GetLatestVersionsIncludingPrerelease(moduleNames0 *uint8, moduleNames1 uint32) (result *cm.Result[string, string, string]) {
+	moduleNames := cm.LiftString[string]((*uint8)(moduleNames0), (uint32)(moduleNames1))
+	result_ := Exports.GetLatestVersionsIncludingPrerelease(moduleNames)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#version-exists
+//export local:gomodule-server/gomodule#version-exists
+func wasmexport_VersionExists(module0 *uint8, module1 uint32, version0 *uint8, version1 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	version := cm.LiftString[string]((*uint8)(version0), (uint32)(version1))
+	result_ := Exports.VersionExists(module, version)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#get-incompatible-versions
+//export local:gomodule-server/gomodule#get-incompatible-versions
+func wasmexport_GetIncompatibleVersions(module0 *uint8, module1 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	result_ := Exports.GetIncompatibleVersions(module)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#get-go-version
+//export local:gomodule-server/gomodule#get-go-version
+func wasmexport_GetGoVersion(moduleNames0 *uint8, moduleNames1 uint32) (result *cm.Result[string, string, string]) {
+	moduleNames := cm.LiftString[string]((*uint8)(moduleNames0), (uint32)(moduleNames1))
+	result_ := Exports.GetGoVersion(moduleNames)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#suggest-next-version
+//export local:gomodule-server/gomodule#suggest-next-version
+func wasmexport_SuggestNextVersion(module0 *uint8, module1 uint32, bump0 *uint8, bump1 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	bump := cm.LiftString[string]((*uint8)(bump0), (uint32)(bump1))
+	result_ := Exports.SuggestNextVersion(module, bump)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#get-metrics
+//export local:gomodule-server/gomodule#get-metrics
+func wasmexport_GetMetrics() (result *cm.Result[string, string, string]) {
+	result_ := Exports.GetMetrics()
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#diff-dependencies
+//export local:gomodule-server/gomodule#diff-dependencies
+func wasmexport_DiffDependencies(module0 *uint8, module1 uint32, fromVersion0 *uint8, fromVersion1 uint32, toVersion0 *uint8, toVersion1 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	fromVersion := cm.LiftString[string]((*uint8)(fromVersion0), (uint32)(fromVersion1))
+	toVersion := cm.LiftString[string]((*uint8)(toVersion0), (uint32)(toVersion1))
+	result_ := Exports.DiffDependencies(module, fromVersion, toVersion)
+	result = &result_
+	return
+}
+
+//go:wasmexport local:gomodule-server/gomodule#resolve-query
+//export local:gomodule-server/gomodule#resolve-query
+func wasmexport_ResolveQuery(module0 *uint8, module1 uint32, query0 *uint8, query1 uint32) (result *cm.Result[string, string, string]) {
+	module := cm.LiftString[string]((*uint8)(module0), (uint32)(module1))
+	query := cm.LiftString[string]((*uint8)(query0), (uint32)(query1))
+	result_ := Exports.ResolveQuery(module, query)
+	result = &result_
+	return
+}
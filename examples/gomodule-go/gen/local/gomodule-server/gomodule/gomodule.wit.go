@@ -2,3 +2,37 @@
 
 // Package gomodule represents the exported interface "local:gomodule-server/gomodule".
 package gomodule
+
+import (
+	"go.bytecodealliance.org/cm"
+)
+
+// ModuleVersion represents the record "local:gomodule-server/gomodule#module-version".
+//
+// A resolved version of a Go module as reported by the module proxy
+//
+//	record module-version {
+//		path: string,
+//		version: string,
+//		time: string,
+//		error: option<string>,
+//		deprecated: option<string>,
+//	}
+type ModuleVersion struct {
+	_ cm.HostLayout `json:"-"`
+
+	// Module path, e.g. github.com/urfave/cli
+	Path string `json:"path"`
+
+	// Resolved version, e.g. v1.22.16
+	Version string `json:"version"`
+
+	// Commit time in RFC 3339 format
+	Time string `json:"time"`
+
+	// Set when the module could not be fetched; version and time are empty then
+	Error cm.Option[string] `json:"error"`
+
+	// The "// Deprecated:" notice of the module's go.mod at this version, if any
+	Deprecated cm.Option[string] `json:"deprecated"`
+}
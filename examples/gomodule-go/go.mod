@@ -5,4 +5,5 @@ go 1.23.2
 require (
 	github.com/ydnar/wasi-http-go v0.0.0-20250620060720-9877ebcf27b5
 	go.bytecodealliance.org/cm v0.2.2
+	golang.org/x/mod v0.27.0
 )
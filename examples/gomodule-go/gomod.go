@@ -0,0 +1,658 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.bytecodealliance.org/cm"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+type GetGoModResult = cm.Result[string, string, string]
+type GetModuleDependenciesResult = cm.Result[string, string, string]
+type GetRetractionsResult = cm.Result[string, string, string]
+type CheckDeprecationResult = cm.Result[string, string, string]
+type GetMinGoVersionResult = cm.Result[string, string, string]
+type GetToolchainInfoResult = cm.Result[string, string, string]
+type GetGoVersionResult = cm.Result[string, string, string]
+type GetReplaceDirectivesResult = cm.Result[string, string, string]
+type ListDependenciesResult = cm.Result[string, string, string]
+
+// toolchainInfo holds the go and toolchain directives of a go.mod file.
+type toolchainInfo struct {
+	Module       string `json:"module"`
+	Version      string `json:"version"`
+	Go           string `json:"go"`
+	Toolchain    string `json:"toolchain,omitempty"`
+	HasToolchain bool   `json:"has_toolchain"`
+}
+
+// dependency is a single require directive from a go.mod file.
+type dependency struct {
+	Path     string `json:"path"`
+	Version  string `json:"version"`
+	Indirect bool   `json:"indirect"`
+}
+
+// replacement is a single replace directive from a go.mod file. Local is set
+// when the module is replaced by a filesystem path, which cannot resolve
+// outside the module's own repository.
+type replacement struct {
+	OldPath    string `json:"old_path"`
+	OldVersion string `json:"old_version,omitempty"`
+	NewPath    string `json:"new_path"`
+	NewVersion string `json:"new_version,omitempty"`
+	Local      bool   `json:"local"`
+}
+
+// exclusion is a single exclude directive from a go.mod file.
+type exclusion struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+}
+
+// dependencyList is the full set of dependency directives of a go.mod file.
+type dependencyList struct {
+	Module   string        `json:"module"`
+	Version  string        `json:"version"`
+	Requires []dependency  `json:"requires"`
+	Replaces []replacement `json:"replaces"`
+	Excludes []exclusion   `json:"excludes"`
+}
+
+// getGoMod returns the raw go.mod of moduleName at version, resolving @latest
+// first when version is empty. Errors are prefixed with "module not found",
+// "version not found" or "transport failure" so callers can tell them apart.
+func getGoMod(ctx context.Context, moduleName, version string) GetGoModResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[GetGoModResult](invalidInput("Module name must not be empty"))
+	}
+
+	if version == "" {
+		latest, err := resolveLatestVersion(ctx, moduleName)
+		if err != nil {
+			return fail[GetGoModResult](err)
+		}
+		version = latest
+	}
+
+	data, err := fetchGoMod(ctx, moduleName, version)
+	if err != nil {
+		return fail[GetGoModResult](err)
+	}
+
+	return cm.OK[GetGoModResult](string(data))
+}
+
+// getModuleDependencies lists the require directives in the go.mod of
+// moduleName at version, resolving @latest first when version is empty.
+func getModuleDependencies(ctx context.Context, moduleName, version string) GetModuleDependenciesResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[GetModuleDependenciesResult](invalidInput("Module name must not be empty"))
+	}
+
+	file, _, err := fetchModFile(ctx, moduleName, version)
+	if err != nil {
+		return fail[GetModuleDependenciesResult](err)
+	}
+
+	deps := []dependency{}
+	for _, req := range file.Require {
+		deps = append(deps, dependency{
+			Path:     req.Mod.Path,
+			Version:  req.Mod.Version,
+			Indirect: req.Indirect,
+		})
+	}
+
+	jsonData, err := json.Marshal(deps)
+	if err != nil {
+		return fail[GetModuleDependenciesResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[GetModuleDependenciesResult](string(jsonData))
+}
+
+// retraction is a retract directive together with the published versions
+// that fall inside it.
+type retraction struct {
+	Low       string   `json:"low"`
+	High      string   `json:"high"`
+	Rationale string   `json:"rationale"`
+	Versions  []string `json:"versions"`
+}
+
+// retractionReport lists the retractions declared by a module's latest go.mod.
+type retractionReport struct {
+	Module      string       `json:"module"`
+	Version     string       `json:"version"`
+	Retractions []retraction `json:"retractions"`
+}
+
+// getRetractions reports the retract directives of moduleName's latest
+// go.mod, annotated with the published versions each one covers.
+func getRetractions(ctx context.Context, moduleName string) GetRetractionsResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[GetRetractionsResult](invalidInput("Module name must not be empty"))
+	}
+
+	file, version, err := fetchModFile(ctx, moduleName, "")
+	if err != nil {
+		return fail[GetRetractionsResult](err)
+	}
+
+	data, err := proxyRequest(ctx, escapedListPath(moduleName))
+	if err != nil {
+		return fail[GetRetractionsResult](fmt.Errorf("Failed to list versions of %s: %w", moduleName, err))
+	}
+	published := parseVersionList(data)
+	sortVersions(published)
+
+	report := retractionReport{Module: moduleName, Version: version, Retractions: []retraction{}}
+	for _, retract := range file.Retract {
+		covered := []string{}
+		for _, v := range published {
+			if semver.Compare(v, retract.Low) >= 0 && semver.Compare(v, retract.High) <= 0 {
+				covered = append(covered, v)
+			}
+		}
+
+		report.Retractions = append(report.Retractions, retraction{
+			Low:       retract.Low,
+			High:      retract.High,
+			Rationale: retract.Rationale,
+			Versions:  covered,
+		})
+	}
+
+	jsonData, err := json.Marshal(report)
+	if err != nil {
+		return fail[GetRetractionsResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[GetRetractionsResult](string(jsonData))
+}
+
+// retractedVersion notes a version that was skipped because it is retracted.
+type retractedVersion struct {
+	Version   string `json:"version"`
+	Rationale string `json:"rationale"`
+}
+
+// skipRetracted checks version, the @latest of moduleName, against the
+// retract directives of its own go.mod. If it is retracted, the highest
+// published version that is not is returned instead, preferring releases to
+// prereleases as the go command does, together with a note of the skipped
+// version.
+func skipRetracted(ctx context.Context, moduleName, version string) (string, *retractedVersion, error) {
+	file, _, err := fetchModFile(ctx, moduleName, version)
+	if err != nil {
+		return "", nil, err
+	}
+
+	rationale, retracted := retractionFor(file, version)
+	if !retracted {
+		return version, nil, nil
+	}
+	skipped := &retractedVersion{Version: version, Rationale: rationale}
+
+	data, err := proxyRequest(ctx, escapedListPath(moduleName))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to list versions of %s: %w", moduleName, err)
+	}
+	published := parseVersionList(data)
+	sortVersions(published)
+
+	for _, prerelease := range []bool{false, true} {
+		for i := len(published) - 1; i >= 0; i-- {
+			v := published[i]
+			if (semver.Prerelease(v) != "") != prerelease {
+				continue
+			}
+			if _, retracted := retractionFor(file, v); !retracted {
+				return v, skipped, nil
+			}
+		}
+	}
+
+	return "", nil, fmt.Errorf("every published version of %s is retracted", moduleName)
+}
+
+// retractionFor reports whether version falls inside a retract directive of
+// file, and that directive's rationale.
+func retractionFor(file *modfile.File, version string) (string, bool) {
+	for _, retract := range file.Retract {
+		if semver.Compare(version, retract.Low) >= 0 && semver.Compare(version, retract.High) <= 0 {
+			return retract.Rationale, true
+		}
+	}
+	return "", false
+}
+
+// deprecationStatus is the "// Deprecated:" notice of a module's latest go.mod.
+type deprecationStatus struct {
+	Module     string `json:"module"`
+	Version    string `json:"version"`
+	Deprecated bool   `json:"deprecated"`
+	Message    string `json:"message"`
+}
+
+// deprecationReport describes whether a module, and the newest major
+// version of its path, are deprecated.
+type deprecationReport struct {
+	deprecationStatus
+	// LatestMajor is set when a newer major version path than the input exists.
+	LatestMajor *deprecationStatus `json:"latest_major,omitempty"`
+}
+
+// checkDeprecation reports the deprecation notice in the latest go.mod of
+// moduleName, and of the newest major version path when that differs, since
+// a v1 path is often deprecated in favour of /v2.
+func checkDeprecation(ctx context.Context, moduleName string) CheckDeprecationResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[CheckDeprecationResult](invalidInput("Module name must not be empty"))
+	}
+
+	status, err := moduleDeprecation(ctx, moduleName, "")
+	if err != nil {
+		return fail[CheckDeprecationResult](err)
+	}
+	report := deprecationReport{deprecationStatus: *status}
+
+	major, err := findLatestMajor(ctx, moduleName)
+	if err != nil {
+		return fail[CheckDeprecationResult](err)
+	}
+	if major.Module != moduleName {
+		if report.LatestMajor, err = moduleDeprecation(ctx, major.Module, major.LatestVersion); err != nil {
+			return fail[CheckDeprecationResult](err)
+		}
+	}
+
+	jsonData, err := json.Marshal(report)
+	if err != nil {
+		return fail[CheckDeprecationResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[CheckDeprecationResult](string(jsonData))
+}
+
+// moduleDeprecation reads the deprecation notice from the go.mod of
+// moduleName at version, or at @latest (which may be a pseudo-version)
+// when version is empty.
+func moduleDeprecation(ctx context.Context, moduleName, version string) (*deprecationStatus, error) {
+	file, version, err := fetchModFile(ctx, moduleName, version)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &deprecationStatus{Module: moduleName, Version: version}
+	if file.Module != nil && file.Module.Deprecated != "" {
+		status.Deprecated = true
+		status.Message = file.Module.Deprecated
+	}
+	return status, nil
+}
+
+// getMinGoVersion reports the go directive of the latest go.mod of every
+// module.
+func getMinGoVersion(ctx context.Context, moduleNames string) GetMinGoVersionResult {
+	modules, invalid, err := normalizeModules(moduleNames)
+	if err != nil {
+		return fail[GetMinGoVersionResult](err)
+	}
+	goVersions := make([]string, len(modules))
+	failures := make([]error, len(modules))
+
+	forEachModule(ctx, modules, func(i int, moduleName string) {
+		file, _, err := fetchModFile(ctx, moduleName, "")
+		if err != nil {
+			failures[i] = err
+			return
+		}
+
+		// Very old modules predate the go directive.
+		goVersions[i] = "unspecified"
+		if file.Go != nil {
+			goVersions[i] = file.Go.Version
+		}
+	})
+
+	batch := newBatchResult(invalid)
+	for i, moduleName := range modules {
+		if failures[i] != nil {
+			batch.addError(moduleName, failures[i])
+			continue
+		}
+		batch.add(moduleName, goVersions[i])
+	}
+	return batchOutput[GetMinGoVersionResult](batch, "get minimum Go versions")
+}
+
+// getToolchainInfo reports the go and toolchain directives of the go.mod of
+// moduleName at version, resolving @latest first when version is empty.
+func getToolchainInfo(ctx context.Context, moduleName, version string) GetToolchainInfoResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[GetToolchainInfoResult](invalidInput("Module name must not be empty"))
+	}
+
+	info, err := fetchToolchainInfo(ctx, moduleName, version)
+	if err != nil {
+		return fail[GetToolchainInfoResult](err)
+	}
+
+	jsonData, err := json.Marshal(info)
+	if err != nil {
+		return fail[GetToolchainInfoResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[GetToolchainInfoResult](string(jsonData))
+}
+
+// fetchToolchainInfo reads the go and toolchain directives of the go.mod of
+// moduleName at version (latest when empty). Very old modules predate the
+// go directive; theirs is reported as "unspecified".
+func fetchToolchainInfo(ctx context.Context, moduleName, version string) (*toolchainInfo, error) {
+	file, version, err := fetchModFile(ctx, moduleName, version)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &toolchainInfo{Module: moduleName, Version: version, Go: "unspecified"}
+	if file.Go != nil {
+		info.Go = file.Go.Version
+	}
+	info.Toolchain, info.HasToolchain = directiveValue(file, "toolchain")
+	return info, nil
+}
+
+// getGoVersion is getToolchainInfo for the latest version of every module.
+func getGoVersion(ctx context.Context, moduleNames string) GetGoVersionResult {
+	modules, invalid, err := normalizeModules(moduleNames)
+	if err != nil {
+		return fail[GetGoVersionResult](err)
+	}
+	infos := make([]*toolchainInfo, len(modules))
+	failures := make([]error, len(modules))
+
+	forEachModule(ctx, modules, func(i int, moduleName string) {
+		infos[i], failures[i] = fetchToolchainInfo(ctx, moduleName, "")
+	})
+
+	batch := newBatchResult(invalid)
+	for i, moduleName := range modules {
+		if failures[i] != nil {
+			batch.addError(moduleName, failures[i])
+			continue
+		}
+		batch.add(moduleName, infos[i])
+	}
+	return batchOutput[GetGoVersionResult](batch, "get Go versions")
+}
+
+// getReplaceDirectives lists the replace directives in the go.mod of
+// moduleName at version, resolving @latest first when version is empty. An
+// empty list means the module can be required directly.
+func getReplaceDirectives(ctx context.Context, moduleName, version string) GetReplaceDirectivesResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[GetReplaceDirectivesResult](invalidInput("Module name must not be empty"))
+	}
+
+	file, version, err := fetchModFile(ctx, moduleName, version)
+	if err != nil {
+		return fail[GetReplaceDirectivesResult](err)
+	}
+
+	replaces := []replacement{}
+	for _, args := range directiveArgs(file, "replace") {
+		replace, err := parseReplace(args)
+		if err != nil {
+			return fail[GetReplaceDirectivesResult](fmt.Errorf("Invalid replace directive in go.mod for %s@%s: %w", moduleName, version, err))
+		}
+		replaces = append(replaces, replace)
+	}
+
+	jsonData, err := json.Marshal(replaces)
+	if err != nil {
+		return fail[GetReplaceDirectivesResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[GetReplaceDirectivesResult](string(jsonData))
+}
+
+// listDependencies reports the require, replace and exclude directives of the
+// go.mod of moduleName at version, resolving @latest first when version is
+// empty. Each list is empty rather than null when the directive is absent.
+func listDependencies(ctx context.Context, moduleName, version string) ListDependenciesResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[ListDependenciesResult](invalidInput("Module name must not be empty"))
+	}
+
+	file, version, err := fetchModFile(ctx, moduleName, version)
+	if err != nil {
+		return fail[ListDependenciesResult](err)
+	}
+
+	list := dependencyList{
+		Module:   moduleName,
+		Version:  version,
+		Requires: []dependency{},
+		Replaces: []replacement{},
+		Excludes: []exclusion{},
+	}
+
+	for _, req := range file.Require {
+		list.Requires = append(list.Requires, dependency{
+			Path:     req.Mod.Path,
+			Version:  req.Mod.Version,
+			Indirect: req.Indirect,
+		})
+	}
+
+	for _, args := range directiveArgs(file, "replace") {
+		replace, err := parseReplace(args)
+		if err != nil {
+			return fail[ListDependenciesResult](fmt.Errorf("Invalid replace directive in go.mod for %s@%s: %w", moduleName, version, err))
+		}
+		list.Replaces = append(list.Replaces, replace)
+	}
+
+	for _, args := range directiveArgs(file, "exclude") {
+		if len(args) != 2 {
+			return fail[ListDependenciesResult](fmt.Errorf("Invalid exclude directive in go.mod for %s@%s: %q", moduleName, version, strings.Join(args, " ")))
+		}
+		path, err := unquoteToken(args[0])
+		if err != nil {
+			return fail[ListDependenciesResult](fmt.Errorf("Invalid exclude directive in go.mod for %s@%s: %w", moduleName, version, err))
+		}
+		list.Excludes = append(list.Excludes, exclusion{Path: path, Version: args[1]})
+	}
+
+	jsonData, err := json.Marshal(list)
+	if err != nil {
+		return fail[ListDependenciesResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[ListDependenciesResult](string(jsonData))
+}
+
+// parseReplace interprets the "old [v] => new [v]" arguments of a replace
+// directive.
+func parseReplace(args []string) (replacement, error) {
+	for i, arg := range args {
+		unquoted, err := unquoteToken(arg)
+		if err != nil {
+			return replacement{}, err
+		}
+		args[i] = unquoted
+	}
+
+	arrow := -1
+	for i, arg := range args {
+		if arg == "=>" {
+			arrow = i
+			break
+		}
+	}
+	if arrow != 1 && arrow != 2 || len(args)-arrow-1 < 1 || len(args)-arrow-1 > 2 {
+		return replacement{}, fmt.Errorf("expected \"old [v] => new [v]\", got %q", strings.Join(args, " "))
+	}
+
+	replace := replacement{OldPath: args[0], NewPath: args[arrow+1]}
+	if arrow == 2 {
+		replace.OldVersion = args[1]
+	}
+	if len(args) == arrow+3 {
+		replace.NewVersion = args[arrow+2]
+	}
+	replace.Local = replace.NewVersion == "" && modfile.IsDirectoryPath(replace.NewPath)
+	return replace, nil
+}
+
+// unquoteToken strips the quotes go.mod allows around any token.
+func unquoteToken(token string) (string, error) {
+	if !strings.HasPrefix(token, `"`) {
+		return token, nil
+	}
+	return strconv.Unquote(token)
+}
+
+// directiveArgs returns the arguments of every verb directive in file, both
+// single-line and inside a "verb ( ... )" block. Like directiveValue, it
+// reads the syntax tree because lax parsing drops directives such as replace
+// from the typed fields.
+func directiveArgs(file *modfile.File, verb string) [][]string {
+	var args [][]string
+	for _, stmt := range file.Syntax.Stmt {
+		switch stmt := stmt.(type) {
+		case *modfile.Line:
+			if len(stmt.Token) > 0 && stmt.Token[0] == verb {
+				args = append(args, append([]string(nil), stmt.Token[1:]...))
+			}
+		case *modfile.LineBlock:
+			if len(stmt.Token) == 1 && stmt.Token[0] == verb {
+				for _, line := range stmt.Line {
+					args = append(args, append([]string(nil), line.Token...))
+				}
+			}
+		}
+	}
+	return args
+}
+
+// directiveValue returns the argument of the first single-line verb
+// directive in file. Lax parsing leaves directives such as toolchain out of
+// the typed fields, but they are still present in the syntax tree.
+func directiveValue(file *modfile.File, verb string) (string, bool) {
+	for _, stmt := range file.Syntax.Stmt {
+		line, ok := stmt.(*modfile.Line)
+		if ok && len(line.Token) == 2 && line.Token[0] == verb {
+			return line.Token[1], true
+		}
+	}
+	return "", false
+}
+
+// fetchModFile downloads and parses the go.mod of moduleName at version,
+// resolving @latest first when version is empty. It returns the parsed file
+// together with the version it was fetched at.
+func fetchModFile(ctx context.Context, moduleName, version string) (*modfile.File, string, error) {
+	if version == "" {
+		latest, err := resolveLatestVersion(ctx, moduleName)
+		if err != nil {
+			return nil, "", err
+		}
+		version = latest
+	}
+
+	data, err := fetchGoMod(ctx, moduleName, version)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// A dependency's go.mod is parsed leniently, as the go command does, so
+	// directives unknown to this version of x/mod do not fail the request.
+	file, err := modfile.ParseLax(moduleName+"@"+version+"/go.mod", data, nil)
+	if err != nil {
+		return nil, "", describe(err, "failed to parse go.mod for %s@%s: %s", moduleName, version, parseErrorText(err))
+	}
+
+	return file, version, nil
+}
+
+// parseErrorText joins the entries of a modfile parse error onto one line.
+// Each entry starts with "<file>:<line>:" so the offending line is named.
+func parseErrorText(err error) string {
+	var errs modfile.ErrorList
+	if !errors.As(err, &errs) {
+		return err.Error()
+	}
+
+	messages := make([]string, len(errs))
+	for i := range errs {
+		messages[i] = errs[i].Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// resolveLatestVersion asks the proxy which version @latest refers to.
+func resolveLatestVersion(ctx context.Context, moduleName string) (string, error) {
+	escapedPath, err := module.EscapePath(moduleName)
+	if err != nil {
+		return "", invalidInput("invalid module path %s: %v", moduleName, err)
+	}
+
+	data, err := proxyRequest(ctx, escapedPath+"/@latest")
+	if err != nil {
+		if isNotFound(err) {
+			return "", describe(err, "module not found: %s", moduleName)
+		}
+		return "", fmt.Errorf("transport failure: fetching %s@latest: %w", moduleName, err)
+	}
+
+	var info struct{ Version string }
+	if err := json.Unmarshal(data, &info); err != nil {
+		return "", fmt.Errorf("failed to resolve latest version for %s: %w", moduleName, err)
+	}
+	if info.Version == "" {
+		return "", fmt.Errorf("failed to resolve latest version for %s: no version in @latest response", moduleName)
+	}
+
+	return info.Version, nil
+}
+
+// fetchGoMod downloads the go.mod of moduleName at version. The version is
+// only escaped, never rewritten, so pseudo-versions such as
+// v0.0.0-20210101000000-abcdef123456 reach the proxy unmodified.
+func fetchGoMod(ctx context.Context, moduleName, version string) ([]byte, error) {
+	path, err := proxyVersionPath(moduleName, version, ".mod")
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := proxyRequest(ctx, path)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, describe(err, "version not found: version %s not found for %s", version, moduleName)
+		}
+		return nil, fmt.Errorf("transport failure: fetching %s@%s: %w", moduleName, version, err)
+	}
+
+	return data, nil
+}
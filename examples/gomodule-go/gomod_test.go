@@ -0,0 +1,65 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"golang.org/x/mod/modfile"
+)
+
+func TestDirectiveValue(t *testing.T) {
+	file, err := modfile.ParseLax("go.mod", []byte("module example.com/m\n\ngo 1.21\n\ntoolchain go1.22.1\n"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		verb  string
+		want  string
+		found bool
+	}{
+		{"go", "1.21", true},
+		{"toolchain", "go1.22.1", true},
+		{"godebug", "", false},
+	}
+	for _, tt := range tests {
+		if got, found := directiveValue(file, tt.verb); got != tt.want || found != tt.found {
+			t.Errorf("directiveValue(%q) = %q, %v; want %q, %v", tt.verb, got, found, tt.want, tt.found)
+		}
+	}
+}
+
+func TestFetchModFileParseError(t *testing.T) {
+	fakeRoutes(t, map[string]string{
+		"proxy.test/example.com/m/@v/v1.0.0.mod": "module example.com/m\n\nrequire (\n",
+	})
+
+	_, _, err := fetchModFile(context.Background(), "example.com/m", "v1.0.0")
+	if err == nil {
+		t.Fatal("fetchModFile accepted a malformed go.mod")
+	}
+	if got := classifyError(err); got != "parse_error" {
+		t.Errorf("classifyError(%v) = %q, want parse_error", err, got)
+	}
+	if !strings.Contains(err.Error(), "example.com/m@v1.0.0/go.mod:") {
+		t.Errorf("error %q does not name the offending line", err)
+	}
+}
+
+func TestResolveLatestVersionParseError(t *testing.T) {
+	fakeRoutes(t, map[string]string{
+		"proxy.test/example.com/m/@latest": `{"Version":`,
+	})
+
+	_, err := resolveLatestVersion(context.Background(), "example.com/m")
+	if err == nil {
+		t.Fatal("resolveLatestVersion accepted a truncated @latest response")
+	}
+	if got := classifyError(err); got != "parse_error" {
+		t.Errorf("classifyError(%v) = %q, want parse_error", err, got)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.bytecodealliance.org/cm"
+)
+
+type VerifyGoSumResult = cm.Result[string, string, string]
+
+// goSumLookupWorkers bounds the checksum database lookups verifyGoSum runs
+// at once, so a large go.sum does not open a connection per module.
+const goSumLookupWorkers = 8
+
+// goSumEntry is the verdict on one go.sum line. Kind is "zip" or "go.mod".
+// Status is "match", "mismatch", "not_found" when the checksum database has
+// no record, "skipped" for modules excluded by GONOSUMDB, GOPRIVATE or
+// GOSUMDB=off, "invalid" for lines that are not go.sum entries, or "error".
+type goSumEntry struct {
+	Line     int    `json:"line"`
+	Module   string `json:"module,omitempty"`
+	Version  string `json:"version,omitempty"`
+	Kind     string `json:"kind,omitempty"`
+	Hash     string `json:"hash,omitempty"`
+	Expected string `json:"expected,omitempty"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+}
+
+// goSumVerification is the result of verifyGoSum. Verified is set only when
+// every entry matched.
+type goSumVerification struct {
+	Verified   bool         `json:"verified"`
+	Mismatches int          `json:"mismatches"`
+	Warning    string       `json:"warning,omitempty"`
+	Entries    []goSumEntry `json:"entries"`
+}
+
+// verifyGoSum checks each line of a pasted go.sum against the checksum
+// database. The zip and go.mod lines of a module version share one lookup.
+func verifyGoSum(ctx context.Context, goSumContents string) VerifyGoSumResult {
+	var entries []goSumEntry
+	var keys []string
+	lookups := make(map[string]int)
+	for i, line := range strings.Split(goSumContents, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		entry := goSumEntry{Line: i + 1}
+		if len(fields) != 3 || !strings.HasPrefix(fields[2], "h1:") {
+			entry.Status = "invalid"
+			entry.Error = "expected \"<module> <version>[/go.mod] h1:<hash>\""
+			entries = append(entries, entry)
+			continue
+		}
+
+		entry.Module, entry.Hash, entry.Kind = fields[0], fields[2], "zip"
+		if version, ok := strings.CutSuffix(fields[1], "/go.mod"); ok {
+			entry.Version, entry.Kind = version, "go.mod"
+		} else {
+			entry.Version = fields[1]
+		}
+
+		key := entry.Module + "@" + entry.Version
+		if _, ok := lookups[key]; !ok {
+			lookups[key] = len(keys)
+			keys = append(keys, key)
+		}
+		entries = append(entries, entry)
+	}
+
+	if len(entries) == 0 {
+		return fail[VerifyGoSumResult](invalidInput("go.sum must not be empty"))
+	}
+
+	records := make([]*sumDBRecord, len(keys))
+	failures := make([]error, len(keys))
+	forEachModuleLimit(ctx, keys, goSumLookupWorkers, func(i int, key string) {
+		moduleName, version, _ := strings.Cut(key, "@")
+		records[i], failures[i] = lookupChecksums(ctx, moduleName, version)
+	})
+
+	result := goSumVerification{Entries: entries, Verified: true}
+	for i := range result.Entries {
+		entry := &result.Entries[i]
+		if entry.Status == "invalid" {
+			result.Verified = false
+			continue
+		}
+
+		lookup := lookups[entry.Module+"@"+entry.Version]
+		if err := failures[lookup]; err != nil {
+			var private *privateModuleError
+			switch {
+			case isNotFound(err):
+				entry.Status = "not_found"
+			case errors.As(err, &private):
+				entry.Status = "skipped"
+			default:
+				entry.Status = "error"
+			}
+			entry.Error = err.Error()
+			result.Verified = false
+			continue
+		}
+
+		entry.Expected = records[lookup].ZipHash
+		if entry.Kind == "go.mod" {
+			entry.Expected = records[lookup].GoModHash
+		}
+		if entry.Hash == entry.Expected {
+			entry.Status = "match"
+			continue
+		}
+		entry.Status = "mismatch"
+		result.Mismatches++
+		result.Verified = false
+	}
+
+	if result.Mismatches > 0 {
+		result.Warning = fmt.Sprintf("%d go.sum entries do not match the checksum database; the module cache or the go.sum may have been tampered with", result.Mismatches)
+	}
+
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		return fail[VerifyGoSumResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[VerifyGoSumResult](string(jsonData))
+}
@@ -0,0 +1,136 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"go.bytecodealliance.org/cm"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+type GetDependencyGraphResult = cm.Result[string, string, string]
+
+// maxGraphNodes bounds how many module versions one dependency graph holds,
+// and so how many go.mod files it downloads.
+const maxGraphNodes = 500
+
+// dependencyGraph is the result of getDependencyGraph. Graph maps each
+// "path@version" node to the nodes it requires; BuildList is the version of
+// every dependency minimal version selection picks.
+type dependencyGraph struct {
+	Root         string              `json:"root"`
+	Depth        int                 `json:"depth"`
+	Nodes        int                 `json:"nodes"`
+	Fetches      int                 `json:"fetches"`
+	NodeLimit    int                 `json:"node_limit"`
+	LimitReached bool                `json:"limit_reached"`
+	Graph        map[string][]string `json:"graph"`
+	BuildList    []selectedVersion   `json:"build_list"`
+	Errors       map[string]string   `json:"errors,omitempty"`
+}
+
+// selectedVersion is an entry of a build list.
+type selectedVersion struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+}
+
+// getDependencyGraph walks the requirements of moduleName at version
+// (latest when empty) breadth-first, maxDepth levels deep (defaultTreeDepth
+// when 0), and applies minimal version selection: each dependency is
+// selected at the highest version any visited node requires. Every
+// module version is visited once, which also breaks cycles.
+func getDependencyGraph(ctx context.Context, moduleName, version string, maxDepth uint32) GetDependencyGraphResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[GetDependencyGraphResult](invalidInput("Module name must not be empty"))
+	}
+
+	if maxDepth == 0 {
+		maxDepth = defaultTreeDepth
+	}
+	if maxDepth > maxTreeDepth {
+		return fail[GetDependencyGraphResult](invalidInput("Depth must be at most %d", maxTreeDepth))
+	}
+
+	if version == "" {
+		latest, err := resolveLatestVersion(ctx, moduleName)
+		if err != nil {
+			return fail[GetDependencyGraphResult](err)
+		}
+		version = latest
+	}
+
+	root := module.Version{Path: moduleName, Version: version}
+	walker := &treeWalker{requires: make(map[string][]dependency), limit: maxGraphNodes}
+	result := dependencyGraph{
+		Root:      root.String(),
+		Depth:     int(maxDepth),
+		NodeLimit: maxGraphNodes,
+		Graph:     make(map[string][]string),
+		Errors:    make(map[string]string),
+	}
+	selected := make(map[string]string)
+	visited := map[string]bool{root.String(): true}
+
+	level := []module.Version{root}
+	for depth := 0; depth < int(maxDepth) && len(level) > 0; depth++ {
+		var next []module.Version
+		for _, node := range level {
+			if ctx.Err() != nil {
+				break
+			}
+			requires, err := walker.fetchRequires(ctx, node.Path, node.Version)
+			if err != nil {
+				if node == root {
+					return fail[GetDependencyGraphResult](err)
+				}
+				result.Errors[node.String()] = err.Error()
+				continue
+			}
+			if requires == nil {
+				continue
+			}
+
+			edges := []string{}
+			for _, req := range requires {
+				dep := module.Version{Path: req.Path, Version: req.Version}
+				edges = append(edges, dep.String())
+				if current, ok := selected[dep.Path]; !ok || semver.Compare(dep.Version, current) > 0 {
+					selected[dep.Path] = dep.Version
+				}
+				if !visited[dep.String()] && dep.Path != root.Path {
+					visited[dep.String()] = true
+					next = append(next, dep)
+				}
+			}
+			result.Graph[node.String()] = edges
+		}
+		level = next
+	}
+
+	delete(selected, root.Path)
+	result.BuildList = []selectedVersion{}
+	for path, version := range selected {
+		result.BuildList = append(result.BuildList, selectedVersion{Path: path, Version: version})
+	}
+	sort.Slice(result.BuildList, func(i, j int) bool {
+		return result.BuildList[i].Path < result.BuildList[j].Path
+	})
+	result.Nodes = len(visited)
+	result.Fetches = walker.fetches
+	result.LimitReached = walker.limitReached
+
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		return fail[GetDependencyGraphResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[GetDependencyGraphResult](string(jsonData))
+}
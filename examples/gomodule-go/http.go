@@ -0,0 +1,707 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"math/rand"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// defaultRequestTimeout bounds each outbound request unless overridden by
+// the GOMODULE_HTTP_TIMEOUT environment variable. It is enforced as a
+// deadline on the request: wasihttp.Transport sends no wasi:http
+// request-options, so the host's connect and first-byte timeouts cannot be
+// set from here.
+const defaultRequestTimeout = 15 * time.Second
+
+// defaultMaxRetries is how many times a transient failure is retried unless
+// overridden by the GOMODULE_MAX_RETRIES environment variable, for at most
+// three attempts in all.
+const defaultMaxRetries = 2
+
+// initialRetryBackoff is the delay before the first retry; it doubles on
+// every following attempt, and each delay is jittered by up to half its
+// length either way so that the goroutines of a batch do not retry in step.
+const initialRetryBackoff = 200 * time.Millisecond
+
+// componentVersion is the version of this component, reported in the
+// default User-Agent.
+const componentVersion = "0.1.0"
+
+var (
+	// userAgent identifies the component to the servers it queries; the
+	// GOMODULE_USER_AGENT environment variable overrides it.
+	userAgent = "wassette-gomodule/" + componentVersion
+	// requestTimeout is the per-request timeout used by httpRequest.
+	requestTimeout = defaultRequestTimeout
+	// maxRetries is how many times httpRequest retries a transient failure.
+	maxRetries = defaultMaxRetries
+	// httpClient sends every request. It is created once, after
+	// requestTimeout is known, and shared by all calls and goroutines:
+	// wasihttp.Transport holds no state, and each round trip creates and
+	// releases its own wasi:http request and response resources, so there is
+	// nothing to reset between requests. Connection reuse, if any, is up to
+	// the host.
+	httpClient *http.Client
+)
+
+// insecure is set by GOMODULE_INSECURE=1 and permits plain http:// URLs,
+// such as proxies in air-gapped test setups, which are refused otherwise. It
+// is initialized before any init function runs so that logging can warn
+// about it. TLS verification cannot be relaxed: wasi:http leaves it to the
+// host.
+var insecure = os.Getenv("GOMODULE_INSECURE") == "1"
+
+// errCancelled is wrapped by the errors of requests abandoned because their
+// context was cancelled, as opposed to timing out.
+var errCancelled = errors.New("cancelled")
+
+// errTimedOut is wrapped by the errors of requests that did not complete
+// within requestTimeout.
+var errTimedOut = errors.New("timed out")
+
+func init() {
+	if value := strings.TrimSpace(os.Getenv("GOMODULE_USER_AGENT")); value != "" {
+		userAgent = value
+	}
+	if value := os.Getenv("GOMODULE_HTTP_TIMEOUT"); value != "" {
+		if timeout, err := time.ParseDuration(value); err == nil && timeout > 0 {
+			requestTimeout = timeout
+		}
+	}
+	if value := os.Getenv("GOMODULE_MAX_RETRIES"); value != "" {
+		if retries, err := strconv.Atoi(value); err == nil && retries >= 0 {
+			maxRetries = retries
+		}
+	}
+
+	httpClient = &http.Client{
+		Transport: transport,
+		Timeout:   requestTimeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			return checkScheme(req.URL.String())
+		},
+	}
+}
+
+// maxRedirects is how many redirects a request follows, so vanity import
+// pages cannot send it round in circles.
+const maxRedirects = 5
+
+// maxErrorBodySize bounds how much of a non-200 response body is kept.
+const maxErrorBodySize = 4 << 10
+
+// maxErrorSnippetSize bounds how much of a non-200 response body an
+// httpStatusError message quotes.
+const maxErrorSnippetSize = 256
+
+// errorHeaders are the response headers an httpStatusError message quotes:
+// they say why a request was refused and when it may be retried.
+var errorHeaders = []string{"X-Go-Error", "Retry-After", "X-RateLimit-Remaining", "X-RateLimit-Reset"}
+
+// htmlTag matches the tags stripped from HTML error pages.
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+// requestError is how every request failure leaves the HTTP layer: the
+// underlying error tagged with the URL, the HTTP status if there was a
+// response, and a category callers can branch on: "not_found" (404),
+// "gone" (410), "rate_limited" (429 or the local rate limit),
+// "client_error" (other 4xx), "server_error" (5xx), "timeout", "cancelled"
+// or "network" (no response).
+type requestError struct {
+	Category string
+	URL      string
+	// Status is 0 when no response was received.
+	Status int
+	Err    error
+}
+
+// Error renders the stable "category: detail (url)" form, e.g.
+// "not_found: HTTP request failed with status: 404 (https://proxy.golang.org/x/@latest)".
+// The URL is left out when the detail already names it.
+func (e *requestError) Error() string {
+	detail := e.Err.Error()
+	if strings.Contains(detail, e.URL) {
+		return e.Category + ": " + detail
+	}
+	return fmt.Sprintf("%s: %s (%s)", e.Category, detail, e.URL)
+}
+
+func (e *requestError) Unwrap() error { return e.Err }
+
+// newRequestError tags err, the failure of a request to url, with its
+// category. Errors that are not about the request, such as an oversized
+// response or a refused plain http:// URL, are returned unchanged.
+func newRequestError(url string, err error) error {
+	var statusErr *httpStatusError
+	var tooLarge *responseTooLargeError
+	reqErr := &requestError{Category: "network", URL: url, Err: err}
+	switch {
+	case errors.As(err, &tooLarge), errors.Is(err, errInsecure):
+		return err
+	case errors.As(err, &statusErr):
+		reqErr.Status = statusErr.StatusCode
+		reqErr.Category = statusCategory(statusErr.StatusCode)
+	case errors.Is(err, errCancelled):
+		reqErr.Category = "cancelled"
+	case errors.Is(err, errTimedOut):
+		reqErr.Category = "timeout"
+	case errors.Is(err, errRateLimit):
+		reqErr.Category = "rate_limited"
+	}
+	return reqErr
+}
+
+// statusCategory is the requestError category of a non-200 HTTP status.
+func statusCategory(code int) string {
+	switch {
+	case code == http.StatusNotFound:
+		return "not_found"
+	case code == http.StatusGone:
+		return "gone"
+	case code == http.StatusTooManyRequests:
+		return "rate_limited"
+	case code >= 500:
+		return "server_error"
+	}
+	return "client_error"
+}
+
+// httpStatusError is returned by httpRequest when the server responds with a
+// non-200 status, so callers can tell missing modules apart from network failures.
+type httpStatusError struct {
+	StatusCode int
+	// Body holds the start of the response body; proxy.golang.org explains
+	// 404 and 410 responses there, e.g. "unknown revision v9.9.9".
+	Body string
+	// Headers holds those of errorHeaders the server sent.
+	Headers http.Header
+}
+
+// Error includes a sanitized snippet of the body and the errorHeaders, e.g.
+// "HTTP request failed with status: 429: too many requests (Retry-After: 30)".
+func (e *httpStatusError) Error() string {
+	message := fmt.Sprintf("HTTP request failed with status: %d", e.StatusCode)
+	if snippet := bodySnippet(e.Body); snippet != "" {
+		message += ": " + snippet
+	}
+
+	var headers []string
+	for _, name := range errorHeaders {
+		if value := e.Headers.Get(name); value != "" {
+			headers = append(headers, name+": "+value)
+		}
+	}
+	if len(headers) > 0 {
+		message += " (" + strings.Join(headers, ", ") + ")"
+	}
+	return message
+}
+
+// bodySnippet reduces an error response body to one line of at most
+// maxErrorSnippetSize bytes, dropping HTML markup so error pages do not
+// flood the message.
+func bodySnippet(body string) string {
+	if strings.Contains(body, "<") {
+		body = html.UnescapeString(htmlTag.ReplaceAllString(body, " "))
+	}
+	snippet := strings.Join(strings.Fields(body), " ")
+	if len(snippet) <= maxErrorSnippetSize {
+		return snippet
+	}
+
+	cut := maxErrorSnippetSize
+	for cut > 0 && !utf8.RuneStart(snippet[cut]) {
+		cut--
+	}
+	return snippet[:cut] + "..."
+}
+
+// isNotFound reports whether err is a proxy 404 or 410 response.
+func isNotFound(err error) bool {
+	var statusErr *httpStatusError
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+	return statusErr.StatusCode == http.StatusNotFound || statusErr.StatusCode == http.StatusGone
+}
+
+// describedError replaces the message of err while keeping err reachable
+// through errors.As, so helpers like isNotFound still work on it.
+type describedError struct {
+	message string
+	err     error
+}
+
+func (e *describedError) Error() string { return e.message }
+func (e *describedError) Unwrap() error { return e.err }
+
+// describe wraps err with a formatted message.
+func describe(err error, format string, args ...interface{}) error {
+	return &describedError{message: fmt.Sprintf(format, args...), err: err}
+}
+
+// proxyMessage returns the explanation a proxy sent with an error
+// response, or "" if err carries none.
+func proxyMessage(err error) string {
+	var statusErr *httpStatusError
+	if !errors.As(err, &statusErr) {
+		return ""
+	}
+	return strings.TrimSpace(statusErr.Body)
+}
+
+// defaultMaxResponseSize bounds the body of every response whose request
+// sets no larger limit, so that a misbehaving server cannot make the
+// component allocate until the runtime kills it.
+const defaultMaxResponseSize = 8 << 20
+
+// responseTooLargeError is returned when a response body exceeds the size
+// limit of the request. ContentLength is -1 when the server did not send one.
+type responseTooLargeError struct {
+	URL           string
+	ContentLength int64
+	Limit         int64
+}
+
+func (e *responseTooLargeError) Error() string {
+	if e.ContentLength < 0 {
+		return fmt.Sprintf("response from %s exceeded %d bytes", e.URL, e.Limit)
+	}
+	return fmt.Sprintf("response from %s is %d bytes (Content-Length), over the %d byte limit", e.URL, e.ContentLength, e.Limit)
+}
+
+// httpRequest GETs url and returns the response body, serving it from the
+// response cache when a fresh copy is available. Cancelling ctx abandons
+// the request.
+func httpRequest(ctx context.Context, url string) ([]byte, error) {
+	if data, ok := cachedResponse(url); ok {
+		logger.Debug("cache hit", "url", url)
+		cacheHits.Add(1)
+		return data, nil
+	}
+	logger.Debug("cache miss", "url", url)
+	cacheMisses.Add(1)
+
+	// An expired response with an ETag is revalidated rather than fetched
+	// again: a 304 renews it without the body being sent.
+	stale, etag := staleResponse(url)
+	resp, err := sendRequest(ctx, url, requestOptions{ifNoneMatch: etag})
+	if err != nil {
+		return nil, err
+	}
+	if resp.notModified {
+		logger.Debug("cache revalidated", "url", url)
+		cacheResponse(url, stale, etag)
+		return stale, nil
+	}
+
+	cacheResponse(url, resp.body, resp.header.Get("ETag"))
+	return resp.body, nil
+}
+
+// httpPostJSON POSTs payload encoded as JSON to url and returns the
+// response body.
+func httpPostJSON(ctx context.Context, url string, payload interface{}) ([]byte, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request body: %w", err)
+	}
+	resp, err := sendRequest(ctx, url, requestOptions{method: http.MethodPost, body: body})
+	if err != nil {
+		return nil, err
+	}
+	return resp.body, nil
+}
+
+// httpRequestLimit GETs url like httpRequest, but fails with a
+// responseTooLargeError instead of reading more than limit bytes rather
+// than defaultMaxResponseSize. Large downloads such as module zips are not
+// cached.
+func httpRequestLimit(ctx context.Context, url string, limit int64) ([]byte, error) {
+	resp, err := sendRequest(ctx, url, requestOptions{limit: limit})
+	if err != nil {
+		return nil, err
+	}
+	return resp.body, nil
+}
+
+// requestOptions describes a request beyond its URL. The zero value is a
+// plain GET whose response may be up to defaultMaxResponseSize bytes.
+type requestOptions struct {
+	// method defaults to GET.
+	method string
+	// body, when non-nil, is sent as JSON.
+	body []byte
+	// limit, when positive, bounds the size of the response body in place
+	// of defaultMaxResponseSize.
+	limit int64
+	// ifNoneMatch, when set, is sent as If-None-Match, and a 304 response
+	// is then a success with notModified set.
+	ifNoneMatch string
+	// acceptGzip sends Accept-Encoding: gzip; the caller must then handle a
+	// compressed body.
+	acceptGzip bool
+}
+
+// response is the outcome of a successful request.
+type response struct {
+	body   []byte
+	header http.Header
+	// notModified is set for a 304 answer to an If-None-Match request; body
+	// is empty then.
+	notModified bool
+}
+
+// sendRequest performs the request and returns the response body. Network
+// errors and 429 and 5xx responses are retried up to maxRetries times with
+// exponential backoff; other statuses, notably 404 and 410, are returned
+// immediately. Cancelling ctx abandons the request and any retries still
+// pending.
+func sendRequest(ctx context.Context, url string, opts requestOptions) (*response, error) {
+	if opts.method == "" {
+		opts.method = http.MethodGet
+	}
+
+	var resp *response
+	err := withRetries(ctx, url, func() error {
+		var err error
+		resp, err = doRequest(ctx, url, opts)
+		return err
+	})
+	if err != nil {
+		return nil, newRequestError(url, err)
+	}
+	return resp, nil
+}
+
+// withRetries calls do until it succeeds, fails with an error that is
+// not retryable, or maxRetries retries have been made, in which case the
+// error says how many attempts were made. It gives up as soon as ctx is
+// cancelled, including while backing off.
+func withRetries(ctx context.Context, url string, do func() error) error {
+	backoff := initialRetryBackoff
+	for attempt := 1; ; attempt++ {
+		if ctx.Err() != nil {
+			return cancelledError(url)
+		}
+
+		err := do()
+		if err == nil || !isRetryable(err) {
+			if err != nil && !isNotFound(err) && !errors.Is(err, errCancelled) {
+				logger.Error("request failed", "url", url, "attempts", attempt, "error", err)
+			}
+			return err
+		}
+		if attempt > maxRetries {
+			logger.Error("request failed", "url", url, "attempts", attempt, "error", err)
+			if attempt == 1 {
+				return err
+			}
+			return describe(err, "%v (gave up after %d attempts)", err, attempt)
+		}
+
+		delay := backoff/2 + time.Duration(rand.Int63n(int64(backoff)))
+		retryCount.Add(1)
+		logger.Warn("request failed, retrying", "url", url, "attempt", attempt, "max_attempts", maxRetries+1, "error", err, "backoff", delay)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return cancelledError(url)
+		}
+		backoff *= 2
+	}
+}
+
+// isRetryable reports whether err is worth retrying: any failure without a
+// response, a 429, or a server error from the proxy. Other 4xx responses
+// would only fail again.
+func isRetryable(err error) bool {
+	if errors.Is(err, errCancelled) || errors.Is(err, errInsecure) || errors.Is(err, errRateLimit) {
+		return false
+	}
+
+	var tooLarge *responseTooLargeError
+	if errors.As(err, &tooLarge) {
+		return false
+	}
+
+	var statusErr *httpStatusError
+	if !errors.As(err, &statusErr) {
+		return true
+	}
+	switch statusErr.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// doRequest performs a single request, failing if it does not complete
+// within requestTimeout. It asks for a gzip-compressed response and
+// decompresses it, applying opts.limit to the decompressed size so that a
+// small compressed body cannot expand without bound.
+func doRequest(parent context.Context, url string, opts requestOptions) (*response, error) {
+	ctx, cancel := requestContext(parent)
+	defer cancel()
+
+	if opts.limit <= 0 {
+		opts.limit = defaultMaxResponseSize
+	}
+	opts.acceptGzip = true
+	resp, err := openRequest(ctx, url, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotModified {
+		return &response{header: resp.Header, notModified: true}, nil
+	}
+
+	if resp.ContentLength > opts.limit {
+		return nil, &responseTooLargeError{URL: url, ContentLength: resp.ContentLength, Limit: opts.limit}
+	}
+
+	var reader io.Reader = resp.Body
+	contentLength := resp.ContentLength
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		gz, err := gzip.NewReader(resp.Body)
+		switch {
+		case errors.Is(err, io.EOF):
+			reader = strings.NewReader("")
+		case err != nil:
+			return nil, fmt.Errorf("failed to decompress response body: %w", err)
+		default:
+			defer gz.Close()
+			reader = gz
+		}
+		// The limit applies to the decompressed body, which the
+		// Content-Length says nothing about.
+		contentLength = -1
+	}
+	// Read one byte past the limit to detect bodies without a
+	// Content-Length that are too large.
+	reader = io.LimitReader(reader, opts.limit+1)
+
+	respBody, err := io.ReadAll(reader)
+	if int64(len(respBody)) > opts.limit {
+		return nil, &responseTooLargeError{URL: url, ContentLength: contentLength, Limit: opts.limit}
+	}
+	if err != nil {
+		if isCancelled(ctx, err) {
+			return nil, cancelledError(url)
+		}
+		if isTimeout(ctx, err) {
+			return nil, timeoutError(url)
+		}
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return &response{body: respBody, header: resp.Header}, nil
+}
+
+// httpContentLength reports the size of the resource at url, preferably
+// without downloading it. It sends a HEAD request, falling back to a GET when
+// the server does not support HEAD. When the server sends no Content-Length,
+// the GET body is counted as it streams past, without being kept, and
+// measured is set.
+func httpContentLength(ctx context.Context, url string) (length int64, measured bool, err error) {
+	err = withRetries(ctx, url, func() error {
+		var err error
+		length, measured, err = doContentLength(ctx, url)
+		return err
+	})
+	if err != nil {
+		return 0, false, newRequestError(url, err)
+	}
+	return length, measured, nil
+}
+
+// doContentLength performs a single attempt of httpContentLength.
+func doContentLength(parent context.Context, url string) (int64, bool, error) {
+	ctx, cancel := requestContext(parent)
+	defer cancel()
+
+	method := http.MethodHead
+	resp, err := openRequest(ctx, url, requestOptions{method: method})
+	var statusErr *httpStatusError
+	if errors.As(err, &statusErr) && (statusErr.StatusCode == http.StatusMethodNotAllowed || statusErr.StatusCode == http.StatusNotImplemented) {
+		method = http.MethodGet
+		resp, err = openRequest(ctx, url, requestOptions{method: method})
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	if resp.ContentLength >= 0 {
+		resp.Body.Close()
+		return resp.ContentLength, false, nil
+	}
+
+	if method == http.MethodHead {
+		resp.Body.Close()
+		if resp, err = openRequest(ctx, url, requestOptions{method: http.MethodGet}); err != nil {
+			return 0, false, err
+		}
+	}
+	defer resp.Body.Close()
+
+	length, err := io.Copy(io.Discard, resp.Body)
+	if err != nil {
+		if isCancelled(ctx, err) {
+			return 0, false, cancelledError(url)
+		}
+		if isTimeout(ctx, err) {
+			return 0, false, timeoutError(url)
+		}
+		return 0, false, fmt.Errorf("failed to read response: %w", err)
+	}
+	return length, true, nil
+}
+
+// requestContext returns the context for a single request attempt of a
+// call running under parent. The deadline is attached to the request
+// context as well as the client so it applies even where the client's own
+// timer is not honoured. Note that wasihttp.Transport blocks on the
+// response future without consulting the context, so a response that never
+// arrives is only detected once the transport returns.
+func requestContext(parent context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(parent, requestTimeout)
+}
+
+// setRequestHeaders sets the headers every outbound request carries: the
+// User-Agent, the Content-Type of JSON bodies and, for the GitHub API, the
+// GITHUB_TOKEN credentials.
+func setRequestHeaders(req *http.Request, hasBody bool) {
+	req.Header.Set("User-Agent", userAgent)
+	if hasBody {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if githubToken != "" && strings.HasPrefix(req.URL.String(), githubAPIURL+"/") {
+		req.Header.Set("Authorization", "Bearer "+githubToken)
+	}
+}
+
+// openRequest sends the request and returns the response with its body
+// unread; the caller must close it. Non-200 responses are returned as an
+// httpStatusError, except for a 304 to a request with opts.ifNoneMatch.
+func openRequest(ctx context.Context, url string, opts requestOptions) (*http.Response, error) {
+	if err := checkScheme(url); err != nil {
+		return nil, err
+	}
+
+	var reqBody io.Reader
+	if opts.body != nil {
+		reqBody = bytes.NewReader(opts.body)
+	}
+
+	method := opts.method
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	setRequestHeaders(req, opts.body != nil)
+	if opts.ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", opts.ifNoneMatch)
+	}
+	if opts.acceptGzip {
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+
+	if err := limiter.wait(ctx); err != nil {
+		if isCancelled(ctx, err) {
+			return nil, cancelledError(url)
+		}
+		return nil, err
+	}
+
+	start := time.Now()
+	requestCount.Add(1)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		transportErrors.Add(1)
+		logger.Debug("request", "method", method, "url", url, "latency", time.Since(start), "error", err)
+		if isCancelled(ctx, err) {
+			return nil, cancelledError(url)
+		}
+		if isTimeout(ctx, err) {
+			return nil, timeoutError(url)
+		}
+		return nil, fmt.Errorf("HTTP request failed: %w", err)
+	}
+	logger.Debug("request", "method", method, "url", url, "status", resp.StatusCode, "latency", time.Since(start))
+	countStatus(resp.StatusCode)
+
+	if resp.StatusCode == http.StatusNotModified && opts.ifNoneMatch != "" {
+		return resp, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		headers := make(http.Header)
+		for _, name := range errorHeaders {
+			if value := resp.Header.Get(name); value != "" {
+				headers.Set(name, value)
+			}
+		}
+		return nil, &httpStatusError{StatusCode: resp.StatusCode, Body: string(body), Headers: headers}
+	}
+
+	return resp, nil
+}
+
+// isTimeout reports whether err was caused by the request deadline expiring.
+func isTimeout(ctx context.Context, err error) bool {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr interface{ Timeout() bool }
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// isCancelled reports whether err was caused by ctx being cancelled rather
+// than by its deadline expiring.
+func isCancelled(ctx context.Context, err error) bool {
+	return errors.Is(ctx.Err(), context.Canceled) || errors.Is(err, context.Canceled)
+}
+
+// cancelledError is the error of a request to url abandoned by cancellation.
+func cancelledError(url string) error {
+	return fmt.Errorf("request to %s %w", url, errCancelled)
+}
+
+// errInsecure is wrapped by the error of plain http:// requests refused
+// because insecure is not set.
+var errInsecure = errors.New("refusing insecure request")
+
+// checkScheme refuses plain http:// URLs unless insecure is set.
+func checkScheme(url string) error {
+	if !insecure && strings.HasPrefix(strings.ToLower(url), "http://") {
+		return fmt.Errorf("%w to %s: set GOMODULE_INSECURE=1 to allow http:// URLs", errInsecure, url)
+	}
+	return nil
+}
+
+// timeoutError is the error of a request to url that did not complete
+// within requestTimeout.
+func timeoutError(url string) error {
+	return fmt.Errorf("%w after %v contacting %s", errTimedOut, requestTimeout, url)
+}
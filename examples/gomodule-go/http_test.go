@@ -0,0 +1,164 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"container/list"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestStatusCategory(t *testing.T) {
+	tests := []struct {
+		code int
+		want string
+	}{
+		{http.StatusBadRequest, "client_error"},
+		{http.StatusUnauthorized, "client_error"},
+		{http.StatusForbidden, "client_error"},
+		{http.StatusNotFound, "not_found"},
+		{http.StatusGone, "gone"},
+		{http.StatusTooManyRequests, "rate_limited"},
+		{http.StatusInternalServerError, "server_error"},
+		{http.StatusBadGateway, "server_error"},
+		{http.StatusServiceUnavailable, "server_error"},
+		{http.StatusGatewayTimeout, "server_error"},
+	}
+	for _, tt := range tests {
+		if got := statusCategory(tt.code); got != tt.want {
+			t.Errorf("statusCategory(%d) = %q, want %q", tt.code, got, tt.want)
+		}
+	}
+}
+
+// roundTripFunc is an http.RoundTripper that answers every request itself.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// testProxy is the proxy base URL fakeTransport installs.
+const testProxy = "https://proxy.test"
+
+// fakeTransport routes every request through rt for the rest of the test,
+// against testProxy alone, with an empty response cache and no rate limit.
+func fakeTransport(t *testing.T, rt roundTripFunc) {
+	t.Helper()
+
+	client, savedProxies, savedLimiter := httpClient, proxies, limiter
+	httpClient = &http.Client{Transport: rt, Timeout: requestTimeout, CheckRedirect: client.CheckRedirect}
+	proxies = []proxyEntry{{base: testProxy}}
+	limiter = newRateLimiter(0)
+	clearResponseCache()
+	t.Cleanup(func() {
+		httpClient, proxies, limiter = client, savedProxies, savedLimiter
+		clearResponseCache()
+	})
+}
+
+// clearResponseCache empties responseCache.
+func clearResponseCache() {
+	responseCache.Lock()
+	defer responseCache.Unlock()
+	responseCache.entries = make(map[string]*list.Element)
+	responseCache.order.Init()
+}
+
+// textResponse is a response to req with the given status and body.
+func textResponse(req *http.Request, status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode:    status,
+		Header:        make(http.Header),
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+func TestRetries(t *testing.T) {
+	saved := maxRetries
+	maxRetries = 1
+	t.Cleanup(func() { maxRetries = saved })
+
+	tests := []struct {
+		name     string
+		statuses []int
+		calls    int32
+		wantErr  string
+	}{
+		{"retried 503", []int{http.StatusServiceUnavailable, http.StatusOK}, 2, ""},
+		{"retried 429", []int{http.StatusTooManyRequests, http.StatusOK}, 2, ""},
+		{"404 not retried", []int{http.StatusNotFound}, 1, "not_found"},
+		{"400 not retried", []int{http.StatusBadRequest}, 1, "client_error"},
+		{"gave up", []int{http.StatusBadGateway, http.StatusBadGateway}, 2, "gave up after 2 attempts"},
+	}
+	for _, tt := range tests {
+		var calls atomic.Int32
+		fakeTransport(t, func(req *http.Request) (*http.Response, error) {
+			status := tt.statuses[min(int(calls.Add(1)), len(tt.statuses))-1]
+			return textResponse(req, status, "body"), nil
+		})
+
+		_, err := httpRequest(context.Background(), testProxy+"/example.com/m/@v/list")
+		if n := calls.Load(); n != tt.calls {
+			t.Errorf("%s: %d requests, want %d", tt.name, n, tt.calls)
+		}
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("%s: httpRequest failed: %v", tt.name, err)
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("%s: httpRequest error = %v, want one containing %q", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+// TestRevalidate checks that an expired response with an ETag is
+// revalidated with If-None-Match and renewed by a 304.
+func TestRevalidate(t *testing.T) {
+	url := testProxy + "/example.com/m/@v/list"
+	var calls atomic.Int32
+	fakeTransport(t, func(req *http.Request) (*http.Response, error) {
+		if calls.Add(1) > 1 {
+			if got := req.Header.Get("If-None-Match"); got != `"v1"` {
+				t.Errorf("If-None-Match = %q, want %q", got, `"v1"`)
+			}
+			return textResponse(req, http.StatusNotModified, ""), nil
+		}
+		resp := textResponse(req, http.StatusOK, "v1.0.0\n")
+		resp.Header.Set("ETag", `"v1"`)
+		return resp, nil
+	})
+
+	if _, err := httpRequest(context.Background(), url); err != nil {
+		t.Fatal(err)
+	}
+	expireCachedResponse(url)
+
+	data, err := httpRequest(context.Background(), url)
+	if err != nil {
+		t.Fatalf("revalidation failed: %v", err)
+	}
+	if string(data) != "v1.0.0\n" {
+		t.Errorf("revalidated body = %q, want the cached one", data)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("%d requests, want 2", n)
+	}
+	if _, ok := cachedResponse(url); !ok {
+		t.Error("the 304 did not renew the cached response")
+	}
+}
+
+// expireCachedResponse makes the cached response for url expire.
+func expireCachedResponse(url string) {
+	responseCache.Lock()
+	defer responseCache.Unlock()
+	responseCache.entries[url].Value.(*cacheEntry).expires = time.Now().Add(-time.Second)
+}
@@ -0,0 +1,106 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.bytecodealliance.org/cm"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+type GetIncompatibleVersionsResult = cm.Result[string, string, string]
+
+// incompatibleVersions splits a module's versions into those published
+// with a go.mod and the v2+ "+incompatible" tags of a repository that had
+// none. Suggest is "none" when there are no +incompatible versions;
+// otherwise it is "module has proper /vN path" when a major version module
+// path exists and "use +incompatible" when only the +incompatible tags
+// reach v2+. MajorPath names the highest major version module path either
+// way.
+type incompatibleVersions struct {
+	Module             string   `json:"module"`
+	Versions           []string `json:"versions"`
+	LatestVersion      string   `json:"latest_version,omitempty"`
+	Incompatible       []string `json:"incompatible"`
+	LatestIncompatible string   `json:"latest_incompatible,omitempty"`
+	MajorPath          string   `json:"major_path,omitempty"`
+	MajorPathVersion   string   `json:"major_path_version,omitempty"`
+	Suggest            string   `json:"suggest"`
+}
+
+// getIncompatibleVersions lists the module-aware and +incompatible
+// versions of moduleName and probes its /vN paths to suggest which to use.
+func getIncompatibleVersions(ctx context.Context, moduleName string) GetIncompatibleVersionsResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[GetIncompatibleVersionsResult](invalidInput("Module name must not be empty"))
+	}
+
+	data, err := proxyRequest(ctx, escapedListPath(moduleName))
+	if err != nil {
+		if isNotFound(err) {
+			return fail[GetIncompatibleVersionsResult](fmt.Errorf("Module %s not found on the proxy: %w", moduleName, err))
+		}
+		return fail[GetIncompatibleVersionsResult](fmt.Errorf("Failed to fetch %s: %w", moduleName, err))
+	}
+
+	versions := parseVersionList(data)
+	sortVersions(versions)
+
+	result := incompatibleVersions{Module: moduleName, Versions: []string{}, Incompatible: []string{}}
+	for _, v := range versions {
+		if semver.Build(v) == "+incompatible" {
+			result.Incompatible = append(result.Incompatible, v)
+		} else {
+			result.Versions = append(result.Versions, v)
+		}
+	}
+	result.LatestVersion = newestVersion(result.Versions)
+	result.LatestIncompatible = newestVersion(result.Incompatible)
+
+	prefix, _, ok := module.SplitPathVersion(moduleName)
+	if !ok {
+		prefix = moduleName
+	}
+	latest, err := findLatestMajor(ctx, prefix)
+	if err != nil {
+		return fail[GetIncompatibleVersionsResult](err)
+	}
+
+	if latest.Module != prefix {
+		result.MajorPath = latest.Module
+		result.MajorPathVersion = latest.LatestVersion
+	}
+	switch {
+	case len(result.Incompatible) == 0:
+		result.Suggest = "none"
+	case result.MajorPath != "":
+		result.Suggest = "module has proper /vN path"
+	default:
+		result.Suggest = "use +incompatible"
+	}
+
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		return fail[GetIncompatibleVersionsResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[GetIncompatibleVersionsResult](string(jsonData))
+}
+
+// newestVersion returns the latest stable release in versions, or the
+// newest version of any kind when there is none.
+func newestVersion(versions []string) string {
+	if v, ok := latestStable(versions); ok {
+		return v
+	}
+	if len(versions) == 0 {
+		return ""
+	}
+	return versions[len(versions)-1]
+}
@@ -0,0 +1,83 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestGetIncompatibleVersions(t *testing.T) {
+	tests := []struct {
+		name   string
+		module string
+		routes map[string]string
+		want   incompatibleVersions
+	}{
+		{
+			// dgrijalva/jwt-go tagged v2 and v3 without a go.mod, then
+			// published a /v4 module path.
+			name:   "jwt-go",
+			module: "github.com/dgrijalva/jwt-go",
+			routes: map[string]string{
+				"proxy.test/github.com/dgrijalva/jwt-go/@v/list":    "v1.0.0\nv1.0.2\nv2.7.0+incompatible\nv3.0.0+incompatible\nv3.2.0+incompatible\n",
+				"proxy.test/github.com/dgrijalva/jwt-go/@latest":    `{"Version":"v3.2.0+incompatible"}`,
+				"proxy.test/github.com/dgrijalva/jwt-go/v4/@latest": `{"Version":"v4.0.0-preview1"}`,
+			},
+			want: incompatibleVersions{
+				LatestVersion:      "v1.0.2",
+				LatestIncompatible: "v3.2.0+incompatible",
+				MajorPath:          "github.com/dgrijalva/jwt-go/v4",
+				Suggest:            "module has proper /vN path",
+			},
+		},
+		{
+			name:   "only +incompatible",
+			module: "github.com/example/legacy",
+			routes: map[string]string{
+				"proxy.test/github.com/example/legacy/@v/list": "v1.0.0\nv2.0.0+incompatible\n",
+				"proxy.test/github.com/example/legacy/@latest": `{"Version":"v2.0.0+incompatible"}`,
+			},
+			want: incompatibleVersions{
+				LatestVersion:      "v1.0.0",
+				LatestIncompatible: "v2.0.0+incompatible",
+				Suggest:            "use +incompatible",
+			},
+		},
+		{
+			name:   "no +incompatible",
+			module: "github.com/example/modern",
+			routes: map[string]string{
+				"proxy.test/github.com/example/modern/@v/list":    "v1.0.0\n",
+				"proxy.test/github.com/example/modern/@latest":    `{"Version":"v1.0.0"}`,
+				"proxy.test/github.com/example/modern/v2/@latest": `{"Version":"v2.1.0"}`,
+			},
+			want: incompatibleVersions{
+				LatestVersion: "v1.0.0",
+				MajorPath:     "github.com/example/modern/v2",
+				Suggest:       "none",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeRoutes(t, tt.routes)
+			result := getIncompatibleVersions(context.Background(), tt.module)
+			if result.IsErr() {
+				t.Fatal(*result.Err())
+			}
+			var got incompatibleVersions
+			if err := json.Unmarshal([]byte(*result.OK()), &got); err != nil {
+				t.Fatal(err)
+			}
+			if got.LatestVersion != tt.want.LatestVersion || got.LatestIncompatible != tt.want.LatestIncompatible ||
+				got.MajorPath != tt.want.MajorPath || got.Suggest != tt.want.Suggest {
+				t.Errorf("got latest %q, latest incompatible %q, major path %q, suggest %q; want %q, %q, %q, %q",
+					got.LatestVersion, got.LatestIncompatible, got.MajorPath, got.Suggest,
+					tt.want.LatestVersion, tt.want.LatestIncompatible, tt.want.MajorPath, tt.want.Suggest)
+			}
+		})
+	}
+}
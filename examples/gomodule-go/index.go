@@ -0,0 +1,92 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.bytecodealliance.org/cm"
+)
+
+type GetRecentModulesResult = cm.Result[string, string, string]
+
+// indexURL is the module index feed listing newly published module versions.
+const indexURL = "https://index.golang.org/index"
+
+// maxIndexLimit is the most records index.golang.org returns per request.
+const maxIndexLimit = 2000
+
+// defaultIndexWindow is how far back getRecentModules looks when no start
+// time is given.
+const defaultIndexWindow = time.Hour
+
+// indexRecord is a single line of the module index feed.
+type indexRecord struct {
+	Path      string    `json:"path"`
+	Version   string    `json:"version"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// recentModules is the result of getRecentModules.
+type recentModules struct {
+	Since   time.Time     `json:"since"`
+	Records []indexRecord `json:"records"`
+	// Skipped counts malformed lines in the feed that were left out.
+	Skipped int `json:"skipped"`
+}
+
+// getRecentModules lists module versions published to index.golang.org
+// since the given RFC 3339 time (an hour ago when empty), up to limit
+// records (maxIndexLimit when 0 or larger).
+func getRecentModules(ctx context.Context, since string, limit uint32) GetRecentModulesResult {
+	start := time.Now().Add(-defaultIndexWindow).UTC()
+	if since = strings.TrimSpace(since); since != "" {
+		parsed, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return fail[GetRecentModulesResult](invalidInput("Invalid since %q: expected an RFC 3339 time such as 2024-01-02T15:04:05Z", since))
+		}
+		start = parsed
+	}
+
+	if limit == 0 || limit > maxIndexLimit {
+		limit = maxIndexLimit
+	}
+
+	query := url.Values{}
+	query.Set("since", start.Format(time.RFC3339Nano))
+	query.Set("limit", strconv.FormatUint(uint64(limit), 10))
+
+	data, err := httpRequest(ctx, indexURL+"?"+query.Encode())
+	if err != nil {
+		return fail[GetRecentModulesResult](fmt.Errorf("Failed to fetch the module index: %w", err))
+	}
+
+	result := recentModules{Since: start, Records: []indexRecord{}}
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		var record indexRecord
+		if err := json.Unmarshal(line, &record); err != nil || record.Path == "" || record.Version == "" {
+			result.Skipped++
+			continue
+		}
+		result.Records = append(result.Records, record)
+	}
+
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		return fail[GetRecentModulesResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[GetRecentModulesResult](string(jsonData))
+}
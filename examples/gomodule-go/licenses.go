@@ -0,0 +1,154 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"go.bytecodealliance.org/cm"
+)
+
+type ScanLicensesResult = cm.Result[string, string, string]
+
+// licenseScanWorkers bounds how many deps.dev lookups scanLicenses runs at once.
+const licenseScanWorkers = 8
+
+// osiApprovedLicenses are the commonly used OSI-approved SPDX identifiers.
+// They are the default allowlist of scanLicenses.
+var osiApprovedLicenses = []string{
+	"0BSD", "AFL-3.0", "AGPL-3.0", "AGPL-3.0-only", "AGPL-3.0-or-later", "Apache-2.0",
+	"Artistic-2.0", "BSD-2-Clause", "BSD-3-Clause", "BSL-1.0", "CDDL-1.0", "EPL-1.0",
+	"EPL-2.0", "EUPL-1.2", "GPL-2.0", "GPL-2.0-only", "GPL-2.0-or-later", "GPL-3.0",
+	"GPL-3.0-only", "GPL-3.0-or-later", "ISC", "LGPL-2.1", "LGPL-2.1-only",
+	"LGPL-2.1-or-later", "LGPL-3.0", "LGPL-3.0-only", "LGPL-3.0-or-later", "MIT",
+	"MIT-0", "MPL-2.0", "MS-PL", "NCSA", "OFL-1.1", "PostgreSQL", "UPL-1.0",
+	"Unlicense", "Zlib",
+}
+
+// licenseAllowlist holds the acceptable SPDX identifiers, read from the
+// comma-separated GOMODULE_LICENSE_ALLOWLIST environment variable when set.
+var licenseAllowlist = make(map[string]bool)
+
+func init() {
+	ids := osiApprovedLicenses
+	if value := os.Getenv("GOMODULE_LICENSE_ALLOWLIST"); strings.TrimSpace(value) != "" {
+		ids = strings.Split(value, ",")
+	}
+	for _, id := range ids {
+		if id = strings.TrimSpace(id); id != "" {
+			licenseAllowlist[id] = true
+		}
+	}
+}
+
+// flaggedLicense is a dependency whose license needs a human to look at it.
+type flaggedLicense struct {
+	Module  string `json:"module"`
+	Version string `json:"version"`
+	License string `json:"license,omitempty"`
+	Reason  string `json:"reason"`
+}
+
+// licenseScan is the result of scanLicenses.
+type licenseScan struct {
+	Module  string `json:"module"`
+	Version string `json:"version"`
+	// Licenses maps each license expression to the dependencies under it.
+	Licenses map[string][]string `json:"licenses"`
+	Flagged  []flaggedLicense    `json:"flagged"`
+}
+
+// scanLicenses looks up the license of every direct dependency of
+// moduleName at version (latest when empty) on deps.dev, groups the
+// dependencies by license and flags those whose license is unknown or not
+// on the allowlist.
+func scanLicenses(ctx context.Context, moduleName, version string) ScanLicensesResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[ScanLicensesResult](invalidInput("Module name must not be empty"))
+	}
+
+	file, version, err := fetchModFile(ctx, moduleName, version)
+	if err != nil {
+		return fail[ScanLicensesResult](err)
+	}
+
+	var deps []dependency
+	var paths []string
+	for _, req := range file.Require {
+		if !req.Indirect {
+			deps = append(deps, dependency{Path: req.Mod.Path, Version: req.Mod.Version})
+			paths = append(paths, req.Mod.Path)
+		}
+	}
+
+	infos := make([]*depsDevVersion, len(deps))
+	failures := make([]error, len(deps))
+	forEachModuleLimit(ctx, paths, licenseScanWorkers, func(i int, path string) {
+		infos[i], failures[i] = fetchDepsDevVersion(ctx, path, deps[i].Version)
+	})
+
+	scan := licenseScan{Module: moduleName, Version: version, Licenses: make(map[string][]string), Flagged: []flaggedLicense{}}
+	for i, dep := range deps {
+		name := dep.Path + "@" + dep.Version
+		switch {
+		case failures[i] != nil:
+			scan.Flagged = append(scan.Flagged, flaggedLicense{Module: dep.Path, Version: dep.Version, Reason: failures[i].Error()})
+			scan.Licenses["unknown"] = append(scan.Licenses["unknown"], name)
+		case infos[i] == nil || len(infos[i].Licenses) == 0:
+			scan.Flagged = append(scan.Flagged, flaggedLicense{Module: dep.Path, Version: dep.Version, Reason: "no license known to deps.dev"})
+			scan.Licenses["unknown"] = append(scan.Licenses["unknown"], name)
+		default:
+			for _, license := range infos[i].Licenses {
+				scan.Licenses[license] = append(scan.Licenses[license], name)
+				if id, ok := disallowedLicense(license); ok {
+					scan.Flagged = append(scan.Flagged, flaggedLicense{
+						Module:  dep.Path,
+						Version: dep.Version,
+						License: license,
+						Reason:  fmt.Sprintf("%s is not on the allowlist", id),
+					})
+				}
+			}
+		}
+	}
+	for _, names := range scan.Licenses {
+		sort.Strings(names)
+	}
+
+	jsonData, err := json.Marshal(scan)
+	if err != nil {
+		return fail[ScanLicensesResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[ScanLicensesResult](string(jsonData))
+}
+
+// disallowedLicense returns the first identifier in an SPDX license
+// expression that is not on the allowlist. Every identifier must be allowed,
+// even in an OR expression, so a choice of licenses is still reviewed.
+// Exceptions named after WITH are not checked.
+func disallowedLicense(expression string) (string, bool) {
+	replacer := strings.NewReplacer("(", " ", ")", " ")
+	tokens := strings.Fields(replacer.Replace(expression))
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		switch token {
+		case "AND", "OR":
+			continue
+		case "WITH":
+			i++
+			continue
+		}
+		if !licenseAllowlist[strings.TrimSuffix(token, "+")] {
+			return token, true
+		}
+	}
+	return "", false
+}
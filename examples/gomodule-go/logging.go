@@ -0,0 +1,30 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// logger writes structured logs to stderr, which the WASI host forwards. Its
+// verbosity is set by GOMODULE_LOG_LEVEL: "off", "info" (the default:
+// errors and retries) or "debug" (also every outbound request and cache
+// lookup).
+var logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+func init() {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv("GOMODULE_LOG_LEVEL"))) {
+	case "off":
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	case "debug":
+		logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
+
+	if insecure {
+		logger.Warn("GOMODULE_INSECURE is set: plain http:// requests are allowed, so responses can be tampered with in transit")
+	}
+}
@@ -4,138 +4,554 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
-	"net/http"
+	"os"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 
 	"gomodule-server-go/gen/local/gomodule-server/gomodule"
 
-	wasihttp "github.com/ydnar/wasi-http-go/wasihttp"
 	"go.bytecodealliance.org/cm"
+	"golang.org/x/mod/module"
 )
 
 func init() {
-	gomodule.Exports.GetLatestVersions = getLatestVersions
-	gomodule.Exports.GetModuleInfo = getModuleInfo
+	gomodule.Exports.GetLatestVersions = tool2("get-latest-versions", getLatestVersions)
+	gomodule.Exports.GetModuleInfo = tool1("get-module-info", getModuleInfo)
+	gomodule.Exports.ListAllVersions = tool3("list-all-versions", getAllVersions)
+	gomodule.Exports.ListModuleVersions = tool1("list-module-versions", listModuleVersions)
+	gomodule.Exports.GetGoMod = tool2("get-go-mod", getGoMod)
+	gomodule.Exports.GetModuleDependencies = tool2("get-module-dependencies", getModuleDependencies)
+	gomodule.Exports.DetectLatestMajor = tool1("detect-latest-major", detectLatestMajor)
+	gomodule.Exports.CompareVersions = tool3("compare-versions", compareVersions)
+	gomodule.Exports.GetModuleChecksums = tool2("get-module-checksums", getModuleChecksums)
+	gomodule.Exports.GetVersionInfo = tool2("get-version-info", getVersionInfo)
+	gomodule.Exports.ResolveRevision = tool2("resolve-revision", resolveRevision)
+	gomodule.Exports.GetRetractions = tool1("get-retractions", getRetractions)
+	gomodule.Exports.GetLatestStable = tool1("get-latest-stable", getLatestStable)
+	gomodule.Exports.CheckDeprecation = tool1("check-deprecation", checkDeprecation)
+	gomodule.Exports.CheckVulnerabilities = tool1("check-vulnerabilities", checkVulnerabilities)
+	gomodule.Exports.GetMinGoVersion = tool1("get-min-go-version", getMinGoVersion)
+	gomodule.Exports.GetToolchainInfo = tool2("get-toolchain-info", getToolchainInfo)
+	gomodule.Exports.VerifyChecksum = tool2("verify-checksum", verifyChecksum)
+	gomodule.Exports.GetReplaceDirectives = tool2("get-replace-directives", getReplaceDirectives)
+	gomodule.Exports.GetModuleOrigin = tool1("get-module-origin", getModuleOrigin)
+	gomodule.Exports.GetLatestMajor = tool1("get-latest-major", getLatestMajor)
+	gomodule.Exports.GetModuleAge = tool2("get-module-age", getModuleAge)
+	gomodule.Exports.DiffGoMod = tool3("diff-go-mod", diffGoMod)
+	gomodule.Exports.FilterVersions = tool2("filter-versions", filterVersions)
+	gomodule.Exports.GetLatestPerMajor = tool1("get-latest-per-major", getLatestPerMajor)
+	gomodule.Exports.ListDependencies = tool2("list-dependencies", listDependencies)
+	gomodule.Exports.GetRecentModules = tool2("get-recent-modules", getRecentModules)
+	gomodule.Exports.GetDependencyTree = tool3("get-dependency-tree", getDependencyTree)
+	gomodule.Exports.CheckModuleExists = tool1("check-module-exists", checkModuleExists)
+	gomodule.Exports.GetPackageDetails = tool1("get-package-details", getPackageDetails)
+	gomodule.Exports.ListModuleFiles = tool2("list-module-files", listModuleFiles)
+	gomodule.Exports.GetModuleSize = tool2("get-module-size", getModuleSize)
+	gomodule.Exports.Ping = tool0("ping", ping)
+	gomodule.Exports.GetModuleLicense = tool2("get-module-license", getModuleLicense)
+	gomodule.Exports.GetModuleVulnerabilities = tool2("get-module-vulnerabilities", getModuleVulnerabilities)
+	gomodule.Exports.ScanLicenses = tool2("scan-licenses", scanLicenses)
+	gomodule.Exports.GetModuleDependents = tool1("get-module-dependents", getModuleDependents)
+	gomodule.Exports.GetScorecard = tool1("get-scorecard", getScorecard)
+	gomodule.Exports.GetRepositoryScorecard = tool1("get-repository-scorecard", getRepositoryScorecard)
+	gomodule.Exports.GetPublishTimeline = tool2("get-publish-timeline", getPublishTimeline)
+	gomodule.Exports.NormalizeModulePath = tool1("normalize-module-path", normalizeModulePath)
+	gomodule.Exports.ResolvePackageToModule = tool1("resolve-package-to-module", resolvePackageToModule)
+	gomodule.Exports.GetModuleReadme = tool2("get-module-readme", getModuleReadme)
+	gomodule.Exports.CheckUpgrade = tool1("check-upgrade", checkUpgrade)
+	gomodule.Exports.GetReleaseNotes = tool2("get-release-notes", getReleaseNotes)
+	gomodule.Exports.AuditGoMod = tool2("audit-go-mod", auditGoMod)
+	gomodule.Exports.VerifyGoSum = tool1("verify-go-sum", verifyGoSum)
+	gomodule.Exports.GetDependencyGraph = tool3("get-dependency-graph", getDependencyGraph)
+	gomodule.Exports.DecodePseudoVersion = tool1("decode-pseudo-version", decodePseudoVersion)
+	gomodule.Exports.GetLatestVersionsIncludingPrerelease = tool1("get-latest-versions-including-prerelease", getLatestVersionsIncludingPrerelease)
+	gomodule.Exports.VersionExists = tool2("version-exists", versionExists)
+	gomodule.Exports.GetIncompatibleVersions = tool1("get-incompatible-versions", getIncompatibleVersions)
+	gomodule.Exports.GetGoVersion = tool1("get-go-version", getGoVersion)
+	gomodule.Exports.SuggestNextVersion = tool2("suggest-next-version", suggestNextVersion)
+	gomodule.Exports.ValidateModulePath = tool1("validate-module-path", validateModulePath)
+	gomodule.Exports.GetReleaseSummary = tool1("get-release-summary", getReleaseSummary)
+	gomodule.Exports.GetLatestPatch = tool3("get-latest-patch", getLatestPatch)
+	gomodule.Exports.GetMetrics = tool0("get-metrics", getMetrics)
+	gomodule.Exports.DiffDependencies = tool3("diff-dependencies", diffDependencies)
+	gomodule.Exports.ResolveQuery = tool2("resolve-query", resolveQuery)
 }
 
 type GetLatestVersionsResult = cm.Result[string, string, string]
-type GetModuleInfoResult = cm.Result[string, string, string]
+type GetModuleInfoResult = cm.Result[cm.List[gomodule.ModuleVersion], cm.List[gomodule.ModuleVersion], string]
+type ListAllVersionsResult = cm.Result[string, string, string]
+type ListModuleVersionsResult = cm.Result[string, string, string]
+
+// fetchResult is the outcome of fetching a single module's proxy URL.
+type fetchResult struct {
+	module string
+	// path is the module path data was found under: module itself, or the
+	// repository path resolveVanity found for a vanity import path. Later
+	// lookups of the module, such as of its go.mod, must use it.
+	path string
+	// vanityProxy is the module proxy a go-import "mod" tag named, which
+	// served data because the configured proxies do not know the module.
+	// Later lookups only go to the configured proxies, so they are skipped.
+	vanityProxy string
+	data        []byte
+	err         error
+}
+
+// fetchModules requests the proxy path built by pathFor for every module
+// concurrently and returns the outcomes in input order. The goroutines share
+// httpClient, which is safe for concurrent use.
+func fetchModules(ctx context.Context, modules []string, pathFor func(moduleName string) string) []fetchResult {
+	results := make([]fetchResult, len(modules))
+
+	forEachModule(ctx, modules, func(i int, moduleName string) {
+		data, err := proxyRequest(ctx, pathFor(moduleName))
+		results[i] = fetchResult{module: moduleName, path: moduleName, data: data, err: err}
+	})
+
+	return results
+}
+
+// defaultFetchWorkers is how many modules forEachModule works on at once
+// unless overridden by the GOMODULE_CONCURRENCY environment variable, which
+// is capped at maxFetchWorkers.
+const (
+	defaultFetchWorkers = 5
+	maxFetchWorkers     = 10
+)
 
-func httpRequest(url string) ([]byte, error) {
-	client := &http.Client{
-		Transport: &wasihttp.Transport{},
+// fetchWorkers bounds the concurrency of forEachModule.
+var fetchWorkers = defaultFetchWorkers
+
+// forEachModule calls fn for every module on a pool of fetchWorkers
+// goroutines and waits for all of them to return. fn receives the module's
+// index so it can store its result without further synchronisation.
+func forEachModule(ctx context.Context, modules []string, fn func(i int, moduleName string)) {
+	forEachModuleLimit(ctx, modules, fetchWorkers, fn)
+}
+
+// forEachModuleLimit is forEachModule with a pool of workers goroutines.
+// Each worker takes the next module as soon as it is done with the previous
+// one, so a slow module only holds up its own worker. Once ctx is done no
+// further modules are handed out, leaving their results unset; runTool
+// then reports the call as cancelled. Modules already handed out still get
+// their fn call, whose requests fail as cancelled.
+func forEachModuleLimit(ctx context.Context, modules []string, workers int, fn func(i int, moduleName string)) {
+	workers = min(max(workers, 1), len(modules))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				fn(i, modules[i])
+			}
+		}()
 	}
+	for i := range modules {
+		if ctx.Err() != nil {
+			break
+		}
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+}
 
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %v", err)
+// defaultMaxBatch is how many modules one call may name unless overridden by
+// the GOMODULE_MAX_BATCH environment variable.
+const defaultMaxBatch = 50
+
+// maxBatch bounds the modules normalizeModules accepts, so one call cannot
+// fan out into thousands of requests.
+var maxBatch = defaultMaxBatch
+
+func init() {
+	if value := os.Getenv("GOMODULE_MAX_BATCH"); value != "" {
+		if limit, err := strconv.Atoi(value); err == nil && limit > 0 {
+			maxBatch = limit
+		}
 	}
+	if value := os.Getenv("GOMODULE_CONCURRENCY"); value != "" {
+		if workers, err := strconv.Atoi(value); err == nil && workers > 0 {
+			fetchWorkers = min(workers, maxFetchWorkers)
+		}
+	}
+}
 
-	req.Header.Set("User-Agent", "hyper-mcp/1.0")
+// invalidModule is an input module path rejected by normalizeModules.
+type invalidModule struct {
+	module string
+	err    error
+}
 
-	resp, err := client.Do(req)
+// normalizeModules parses a module list into canonical module paths,
+// skipping empty entries and dropping duplicates such as "gorilla/mux" and
+// "github.com/gorilla/mux" while keeping first-seen order. The list is
+// either comma-separated or, when it starts with "[", a JSON array of
+// strings. Paths that module.CheckPath rejects, or a malformed JSON array,
+// are returned separately, so tools can report them without a round-trip
+// to the proxy. Lists of more than maxBatch modules fail as a whole.
+func normalizeModules(moduleNames string) ([]string, []invalidModule, error) {
+	names, err := splitModules(moduleNames)
 	if err != nil {
-		return nil, fmt.Errorf("HTTP request failed: %v", err)
+		return nil, []invalidModule{{module: strings.TrimSpace(moduleNames), err: err}}, nil
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP request failed with status: %d", resp.StatusCode)
+	var modules []string
+	var invalid []invalidModule
+	seen := make(map[string]bool)
+	for _, moduleName := range names {
+		moduleName = defaultModulePath(moduleName)
+		if moduleName == "" || seen[moduleName] {
+			continue
+		}
+		seen[moduleName] = true
+
+		if err := module.CheckPath(moduleName); err != nil {
+			invalid = append(invalid, invalidModule{module: moduleName, err: invalidInput("invalid module path: %v", unwrapModuleError(err))})
+			continue
+		}
+		modules = append(modules, moduleName)
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	if count := len(modules) + len(invalid); count > maxBatch {
+		return nil, nil, invalidInput("too many modules (got %d, max %d)", count, maxBatch)
+	}
+	return modules, invalid, nil
+}
+
+// splitModules splits a comma-separated or JSON array module list into
+// its entries.
+func splitModules(moduleNames string) ([]string, error) {
+	trimmed := strings.TrimSpace(moduleNames)
+	if !strings.HasPrefix(trimmed, "[") {
+		return strings.Split(moduleNames, ","), nil
+	}
+
+	var names []string
+	if err := json.Unmarshal([]byte(trimmed), &names); err != nil {
+		return nil, invalidInput("invalid module list: expected a JSON array of strings: %v", err)
+	}
+	return names, nil
+}
+
+// unwrapModuleError strips the "malformed module path" prefix module.CheckPath
+// puts in front of the reason.
+func unwrapModuleError(err error) error {
+	var moduleErr *module.InvalidPathError
+	if errors.As(err, &moduleErr) {
+		return moduleErr.Err
+	}
+	return err
+}
+
+// partialResults is the output of every batch tool: the results of the
+// modules that succeeded and the errors of those that failed, keyed by
+// module. Both maps are always present, the errors one empty when every
+// module succeeded.
+type partialResults struct {
+	Results map[string]interface{} `json:"results"`
+	Errors  map[string]string      `json:"errors"`
+	// Retracted lists the retracted @latest versions getLatestVersions
+	// skipped, keyed by module.
+	Retracted map[string]*retractedVersion `json:"retracted,omitempty"`
+	// ErrorCategories holds the toolError category of each of Errors.
+	ErrorCategories map[string]string `json:"error_categories"`
+}
+
+// batchResult collects the outcome of a batch tool module by module.
+type batchResult struct {
+	payload  partialResults
+	failures []error
+}
+
+// newBatchResult starts a batch with the modules normalizeModules rejected
+// already among its errors, in input order.
+func newBatchResult(invalid []invalidModule) *batchResult {
+	b := &batchResult{payload: partialResults{
+		Results:         make(map[string]interface{}),
+		Errors:          make(map[string]string),
+		ErrorCategories: make(map[string]string),
+	}}
+	for _, bad := range invalid {
+		b.addError(bad.module, bad.err)
+	}
+	return b
+}
+
+// add records the result of moduleName.
+func (b *batchResult) add(moduleName string, result interface{}) {
+	b.payload.Results[moduleName] = result
+}
+
+// addError records the failure of moduleName.
+func (b *batchResult) addError(moduleName string, err error) {
+	b.payload.Errors[moduleName] = err.Error()
+	b.payload.ErrorCategories[moduleName] = classifyError(err)
+	b.failures = append(b.failures, fmt.Errorf("%s: %w", moduleName, err))
+}
+
+// batchOutput returns the result of a batch tool as its partialResults
+// JSON. Only a batch in which every module failed is an error.
+func batchOutput[R cm.AnyResult[Shape, string, string], Shape any](b *batchResult, what string) R {
+	if len(b.payload.Results) == 0 {
+		return fail[R](batchFailure(what, b.failures))
+	}
+
+	// encoding/json sorts map keys, so the output order is deterministic.
+	jsonData, err := json.Marshal(b.payload)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %v", err)
+		return fail[R](fmt.Errorf("Failed to marshal results: %w", err))
 	}
+	return cm.OK[R](string(jsonData))
+}
 
-	return body, nil
+// batchFailure is the error of a batch in which every module failed: "Failed
+// to <what>" followed by each failure, classified by the first one.
+func batchFailure(what string, failures []error) error {
+	if len(failures) == 0 {
+		return invalidInput("Failed to %s: no modules given", what)
+	}
+	messages := make([]string, len(failures))
+	for i, failure := range failures {
+		messages[i] = failure.Error()
+	}
+	return describe(failures[0], "Failed to %s: %s", what, strings.Join(messages, "; "))
 }
 
-func getLatestVersions(moduleNames string) GetLatestVersionsResult {
-	modules := strings.Split(moduleNames, ",")
-	results := make(map[string]string)
+// getLatestVersions resolves @latest for every module. Unless
+// includeRetracted is set, a retracted @latest is replaced by the newest
+// version that is not retracted, and the skipped version is reported under
+// "retracted".
+func getLatestVersions(ctx context.Context, moduleNames string, includeRetracted bool) GetLatestVersionsResult {
+	modules, invalid, err := normalizeModules(moduleNames)
+	if err != nil {
+		return fail[GetLatestVersionsResult](err)
+	}
+	versions := make([]string, len(modules))
+	skipped := make([]*retractedVersion, len(modules))
+	lookupErrs := make([]error, len(modules))
+
+	fetched := fetchLatestModules(ctx, modules)
+	for i := range fetched {
+		versions[i], lookupErrs[i] = parseLatestVersion(fetched[i])
+	}
+
+	if !includeRetracted {
+		forEachModule(ctx, modules, func(i int, moduleName string) {
+			if lookupErrs[i] == nil && fetched[i].vanityProxy == "" {
+				versions[i], skipped[i], lookupErrs[i] = skipRetracted(ctx, fetched[i].path, versions[i])
+			}
+		})
+	}
 
-	for _, moduleName := range modules {
-		moduleName = strings.TrimSpace(moduleName)
-		if moduleName == "" {
+	batch := newBatchResult(invalid)
+	batch.payload.Retracted = make(map[string]*retractedVersion)
+	for i, moduleName := range modules {
+		if err := lookupErrs[i]; err != nil {
+			batch.addError(moduleName, err)
 			continue
 		}
-
-		if !strings.Contains(moduleName, "/") {
-			moduleName = "github.com/" + moduleName
+		batch.add(moduleName, versions[i])
+		if skipped[i] != nil {
+			batch.payload.Retracted[moduleName] = skipped[i]
 		}
+	}
+	return batchOutput[GetLatestVersionsResult](batch, "get latest versions")
+}
 
-		url := fmt.Sprintf("https://proxy.golang.org/%s/@latest", moduleName)
+// parseLatestVersion extracts the version from a fetched @latest response.
+func parseLatestVersion(fetched fetchResult) (string, error) {
+	if fetched.err != nil {
+		return "", fmt.Errorf("failed to fetch: %w", fetched.err)
+	}
 
-		data, err := httpRequest(url)
-		if err != nil {
-			return cm.Err[GetLatestVersionsResult](fmt.Sprintf("Failed to fetch %s: %v", moduleName, err))
-		}
+	var moduleInfo struct{ Version string }
+	if err := json.Unmarshal(fetched.data, &moduleInfo); err != nil {
+		return "", fmt.Errorf("failed to parse JSON: %w", err)
+	}
 
-		var moduleInfo map[string]interface{}
-		if err := json.Unmarshal(data, &moduleInfo); err != nil {
-			return cm.Err[GetLatestVersionsResult](fmt.Sprintf("Failed to parse JSON for %s: %v", moduleName, err))
-		}
+	if moduleInfo.Version == "" {
+		return "", fmt.Errorf("no version in proxy response")
+	}
+
+	return moduleInfo.Version, nil
+}
 
-		if version, ok := moduleInfo["Version"].(string); ok {
-			results[moduleName] = version
+func getModuleInfo(ctx context.Context, moduleNames string) GetModuleInfoResult {
+	var results []gomodule.ModuleVersion
+	var failures []error
+
+	modules, invalid, err := normalizeModules(moduleNames)
+	if err != nil {
+		return fail[GetModuleInfoResult](err)
+	}
+	for _, bad := range invalid {
+		failures = append(failures, fmt.Errorf("%s: %w", bad.module, bad.err))
+		results = append(results, gomodule.ModuleVersion{
+			Path:  bad.module,
+			Error: cm.Some(bad.err.Error()),
+		})
+	}
+
+	fetched := fetchLatestModules(ctx, modules)
+	infos := make([]gomodule.ModuleVersion, len(modules))
+	infoErrs := make([]error, len(modules))
+	forEachModule(ctx, modules, func(i int, moduleName string) {
+		infos[i], infoErrs[i] = moduleVersionInfo(ctx, fetched[i])
+	})
+
+	for i, info := range infos {
+		if infoErrs[i] != nil {
+			failures = append(failures, fmt.Errorf("%s: %w", info.Path, infoErrs[i]))
 		}
+		results = append(results, info)
 	}
 
-	if len(results) == 0 {
-		return cm.Err[GetLatestVersionsResult]("Failed to get latest versions")
+	if len(failures) == len(results) {
+		return fail[GetModuleInfoResult](batchFailure("get module information", failures))
+	}
+
+	return cm.OK[GetModuleInfoResult](cm.ToList(results))
+}
+
+// moduleVersionInfo turns the @latest lookup of a module into its
+// ModuleVersion, adding the deprecation notice from the go.mod at that
+// version. A go.mod that cannot be read leaves the notice unset rather than
+// failing the module, as getModuleInfo is mostly asked for the version. A
+// failed lookup is returned both as the error and in the ModuleVersion.
+func moduleVersionInfo(ctx context.Context, fetched fetchResult) (gomodule.ModuleVersion, error) {
+	var moduleInfo struct {
+		Version string
+		Time    string
 	}
 
-	jsonData, err := json.Marshal(results)
+	err := fetched.err
+	if err == nil {
+		err = json.Unmarshal(fetched.data, &moduleInfo)
+	}
 	if err != nil {
-		return cm.Err[GetLatestVersionsResult](fmt.Sprintf("Failed to marshal results: %v", err))
+		return gomodule.ModuleVersion{Path: fetched.module, Error: cm.Some(err.Error())}, err
 	}
 
-	return cm.OK[GetLatestVersionsResult](string(jsonData))
+	info := gomodule.ModuleVersion{
+		Path:    fetched.module,
+		Version: moduleInfo.Version,
+		Time:    moduleInfo.Time,
+	}
+	if fetched.vanityProxy != "" {
+		return info, nil
+	}
+	if status, err := moduleDeprecation(ctx, fetched.path, moduleInfo.Version); err != nil {
+		logger.Debug("deprecation lookup failed", "module", fetched.module, "error", err)
+	} else if status.Deprecated {
+		info.Deprecated = cm.Some(status.Message)
+	}
+	return info, nil
 }
 
-func getModuleInfo(moduleNames string) GetModuleInfoResult {
-	modules := strings.Split(moduleNames, ",")
-	var results []map[string]interface{}
-
-	for _, moduleName := range modules {
-		moduleName = strings.TrimSpace(moduleName)
-		if moduleName == "" {
-			continue
-		}
+// defaultVersionPageSize is how many versions list-all-versions returns per
+// module when no limit is given.
+const defaultVersionPageSize = 50
+
+// versionPage is one page of the versions of a module, newest first.
+// HasMore is set when older versions follow the page.
+type versionPage struct {
+	Versions []string `json:"versions"`
+	Total    int      `json:"total"`
+	Offset   int      `json:"offset"`
+	Limit    int      `json:"limit"`
+	HasMore  bool     `json:"has_more"`
+}
 
-		if !strings.Contains(moduleName, "/") {
-			moduleName = "github.com/" + moduleName
-		}
+// getAllVersions lists the versions of every module newest first, skipping
+// the offset newest and returning at most limit (defaultVersionPageSize
+// when 0), so long version histories can be read in pages.
+func getAllVersions(ctx context.Context, moduleNames string, offset, limit uint32) ListAllVersionsResult {
+	if limit == 0 {
+		limit = defaultVersionPageSize
+	}
 
-		url := fmt.Sprintf("https://proxy.golang.org/%s/@latest", moduleName)
+	modules, invalid, err := normalizeModules(moduleNames)
+	if err != nil {
+		return fail[ListAllVersionsResult](err)
+	}
+	batch := newBatchResult(invalid)
 
-		data, err := httpRequest(url)
-		if err != nil {
-			return cm.Err[GetModuleInfoResult](fmt.Sprintf("Failed to fetch %s: %v", moduleName, err))
+	for _, fetched := range fetchModules(ctx, modules, escapedListPath) {
+		if fetched.err != nil {
+			batch.addError(fetched.module, fmt.Errorf("failed to fetch: %w", fetched.err))
+			continue
 		}
 
-		var moduleInfo map[string]interface{}
-		if err := json.Unmarshal(data, &moduleInfo); err != nil {
-			return cm.Err[GetModuleInfoResult](fmt.Sprintf("Failed to parse JSON for %s: %v", moduleName, err))
-		}
+		versions := parseVersionList(fetched.data)
+		sortVersions(versions)
+		slices.Reverse(versions)
+
+		start := min(int(offset), len(versions))
+		end := min(start+int(limit), len(versions))
+		batch.add(fetched.module, versionPage{
+			Versions: versions[start:end],
+			Total:    len(versions),
+			Offset:   int(offset),
+			Limit:    int(limit),
+			HasMore:  end < len(versions),
+		})
+	}
+	return batchOutput[ListAllVersionsResult](batch, "list versions")
+}
 
-		results = append(results, moduleInfo)
+func listModuleVersions(ctx context.Context, moduleName string) ListModuleVersionsResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[ListModuleVersionsResult](invalidInput("Module name must not be empty"))
 	}
 
-	if len(results) == 0 {
-		return cm.Err[GetModuleInfoResult]("Failed to get module information")
+	data, err := proxyRequest(ctx, escapedListPath(moduleName))
+	if err != nil {
+		if isNotFound(err) {
+			return fail[ListModuleVersionsResult](fmt.Errorf("Module %s not found on the proxy: %w", moduleName, err))
+		}
+		return fail[ListModuleVersionsResult](fmt.Errorf("Failed to fetch %s: %w", moduleName, err))
 	}
 
-	jsonData, err := json.Marshal(results)
+	versions := parseVersionList(data)
+	sortVersions(versions)
+
+	jsonData, err := json.Marshal(versions)
 	if err != nil {
-		return cm.Err[GetModuleInfoResult](fmt.Sprintf("Failed to marshal results: %v", err))
+		return fail[ListModuleVersionsResult](fmt.Errorf("Failed to marshal results: %w", err))
 	}
 
-	return cm.OK[GetModuleInfoResult](string(jsonData))
+	return cm.OK[ListModuleVersionsResult](string(jsonData))
+}
+
+// defaultModulePath trims moduleName, lowercases its host and treats names
+// without a host, such as "owner/repo", as GitHub modules, matching how the
+// multi-module tools interpret their input. Trailing slashes and the ".git"
+// of pasted clone URLs are dropped; see canonicalModulePath.
+func defaultModulePath(moduleName string) string {
+	moduleName, _ = canonicalModulePath(moduleName)
+	return moduleName
+}
+
+// parseVersionList splits a newline-delimited @v/list body into versions.
+// An empty body is valid: modules with only pseudo-versions have no tags to list.
+func parseVersionList(data []byte) []string {
+	versions := []string{}
+	for _, line := range strings.Split(string(data), "\n") {
+		if version := strings.TrimSpace(line); version != "" {
+			versions = append(versions, version)
+		}
+	}
+	return versions
 }
 
 func main() {}
@@ -0,0 +1,137 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.bytecodealliance.org/cm"
+)
+
+func TestForEachModuleLimitStopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var calls atomic.Int32
+	forEachModuleLimit(ctx, []string{"a", "b", "c"}, 2, func(int, string) {
+		calls.Add(1)
+	})
+	if n := calls.Load(); n != 0 {
+		t.Errorf("fn called %d times after cancellation, want 0", n)
+	}
+}
+
+// TestFetchModulesConcurrently checks that a batch of slow lookups takes
+// about as long as the slowest one rather than the sum of them all.
+func TestFetchModulesConcurrently(t *testing.T) {
+	const latency = 200 * time.Millisecond
+	var gauge concurrencyGauge
+	fakeTransport(t, func(req *http.Request) (*http.Response, error) {
+		defer gauge.enter()()
+		time.Sleep(latency)
+		return textResponse(req, http.StatusOK, `{"Version":"v1.0.0"}`), nil
+	})
+
+	modules := make([]string, fetchWorkers)
+	for i := range modules {
+		modules[i] = fmt.Sprintf("example.com/m%d", i)
+	}
+
+	start := time.Now()
+	results := fetchModules(context.Background(), modules, escapedLatestPath)
+	elapsed := time.Since(start)
+
+	for _, result := range results {
+		if result.err != nil {
+			t.Fatalf("%s: %v", result.module, result.err)
+		}
+	}
+	if elapsed >= 2*latency {
+		t.Errorf("fetching %d modules took %v, want about %v", len(modules), elapsed, latency)
+	}
+	if got := gauge.peak.Load(); int(got) != fetchWorkers {
+		t.Errorf("at most %d requests in flight, want %d", got, fetchWorkers)
+	}
+}
+
+func TestForEachModuleLimitBoundsWorkers(t *testing.T) {
+	var gauge concurrencyGauge
+	modules := make([]string, 20)
+	forEachModuleLimit(context.Background(), modules, 3, func(int, string) {
+		defer gauge.enter()()
+		time.Sleep(10 * time.Millisecond)
+	})
+	if got := gauge.peak.Load(); got > 3 {
+		t.Errorf("%d calls ran at once, want at most 3", got)
+	}
+}
+
+// concurrencyGauge records the most calls that were ever between enter and
+// the function it returns at the same time.
+type concurrencyGauge struct {
+	inFlight, peak atomic.Int32
+}
+
+func (g *concurrencyGauge) enter() (leave func()) {
+	n := g.inFlight.Add(1)
+	for p := g.peak.Load(); n > p && !g.peak.CompareAndSwap(p, n); p = g.peak.Load() {
+	}
+	return func() { g.inFlight.Add(-1) }
+}
+
+func TestBatchOutput(t *testing.T) {
+	notFound := newRequestError("https://proxy.test/b/@latest", &httpStatusError{StatusCode: 404})
+
+	batch := newBatchResult([]invalidModule{{module: "bad path", err: invalidInput("invalid module path")}})
+	batch.add("a", "v1.0.0")
+	batch.addError("b", notFound)
+	result := batchOutput[cm.Result[string, string, string]](batch, "get versions")
+	if result.IsErr() {
+		t.Fatalf("batchOutput failed: %s", *result.Err())
+	}
+	var got partialResults
+	if err := json.Unmarshal([]byte(*result.OK()), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Results["a"] != "v1.0.0" || got.Errors["b"] != notFound.Error() {
+		t.Errorf("batchOutput = %+v, want a result for a and an error for b", got)
+	}
+	if got.ErrorCategories["b"] != "not_found" || got.ErrorCategories["bad path"] != "invalid_input" {
+		t.Errorf("error categories = %v, want not_found for b and invalid_input for bad path", got.ErrorCategories)
+	}
+
+	// A batch in which every module succeeded keeps the same shape.
+	batch = newBatchResult(nil)
+	batch.add("a", "v1.0.0")
+	result = batchOutput[cm.Result[string, string, string]](batch, "get versions")
+	if result.IsErr() {
+		t.Fatalf("batchOutput failed: %s", *result.Err())
+	}
+	if want := `{"results":{"a":"v1.0.0"},"errors":{},"error_categories":{}}`; *result.OK() != want {
+		t.Errorf("batchOutput of a successful batch = %s, want %s", *result.OK(), want)
+	}
+
+	// A batch in which every module failed is classified by its first failure.
+	batch = newBatchResult(nil)
+	batch.addError("b", notFound)
+	batch.addError("c", errors.New("no version in proxy response"))
+	result = batchOutput[cm.Result[string, string, string]](batch, "get versions")
+	if !result.IsErr() {
+		t.Fatalf("batchOutput of a failed batch = %s, want an error", *result.OK())
+	}
+	var failure toolError
+	if err := json.Unmarshal([]byte(*result.Err()), &failure); err != nil {
+		t.Fatal(err)
+	}
+	if failure.Category != "not_found" || failure.Status != 404 {
+		t.Errorf("failed batch = %+v, want not_found with status 404", failure)
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.bytecodealliance.org/cm"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+type DetectLatestMajorResult = cm.Result[string, string, string]
+type GetLatestPerMajorResult = cm.Result[string, string, string]
+type GetLatestMajorResult = cm.Result[string, string, string]
+
+// maxProbedMajor bounds how many /vN module paths are probed for a module.
+const maxProbedMajor = 20
+
+// majorProbeWorkers bounds how many major version paths getLatestPerMajor
+// probes at once.
+const majorProbeWorkers = 4
+
+// latestMajor describes the newest major version module path of a module.
+type latestMajor struct {
+	Module             string `json:"module"`
+	Major              string `json:"major"`
+	LatestVersion      string `json:"latest_version"`
+	InputIsLatestMajor bool   `json:"input_is_latest_major"`
+}
+
+// majorPaths returns the module path of every major version up to
+// maxProbedMajor, lowest first: the unsuffixed (v0/v1) path followed by
+// /v2, /v3 and so on. gopkg.in paths always name their major, so for them
+// the list runs .v0, .v1, .v2 and so on.
+func majorPaths(moduleName string) []string {
+	prefix, _, ok := module.SplitPathVersion(moduleName)
+	if !ok {
+		prefix = moduleName
+	}
+
+	if strings.HasPrefix(prefix, "gopkg.in/") {
+		var paths []string
+		for major := 0; major <= maxProbedMajor; major++ {
+			paths = append(paths, fmt.Sprintf("%s.v%d", prefix, major))
+		}
+		return paths
+	}
+
+	paths := []string{prefix}
+	for major := 2; major <= maxProbedMajor; major++ {
+		paths = append(paths, fmt.Sprintf("%s/v%d", prefix, major))
+	}
+	return paths
+}
+
+// escapedLatestPath is the @latest path of moduleName, case-encoded for the proxy.
+func escapedLatestPath(moduleName string) string {
+	return escapeModulePath(moduleName) + "/@latest"
+}
+
+func detectLatestMajor(ctx context.Context, moduleName string) DetectLatestMajorResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[DetectLatestMajorResult](invalidInput("Module name must not be empty"))
+	}
+
+	result, err := findLatestMajor(ctx, moduleName)
+	if err != nil {
+		return fail[DetectLatestMajorResult](err)
+	}
+
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		return fail[DetectLatestMajorResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[DetectLatestMajorResult](string(jsonData))
+}
+
+// findLatestMajor probes every major version path of moduleName
+// concurrently and returns the highest one the proxy knows about. A 404 or
+// 410 only means that major does not exist; any other failure leaves the
+// answer unknown and is returned.
+func findLatestMajor(ctx context.Context, moduleName string) (*latestMajor, error) {
+	probes := fetchModules(ctx, majorPaths(moduleName), escapedLatestPath)
+
+	for i := len(probes) - 1; i >= 0; i-- {
+		probe := probes[i]
+		if probe.err != nil {
+			if isNotFound(probe.err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to fetch %s: %w", probe.module, probe.err)
+		}
+
+		var info struct{ Version string }
+		if err := json.Unmarshal(probe.data, &info); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON for %s: %w", probe.module, err)
+		}
+
+		return &latestMajor{
+			Module:             probe.module,
+			Major:              semver.Major(info.Version),
+			LatestVersion:      info.Version,
+			InputIsLatestMajor: probe.module == moduleName,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("module %s not found on the proxy at any major version up to v%d", moduleName, maxProbedMajor)
+}
+
+// resolvedModule is the module path and version a user-supplied path
+// resolved to.
+type resolvedModule struct {
+	Module  string `json:"module"`
+	Version string `json:"version"`
+}
+
+// getLatestMajor resolves @latest for every module like getLatestVersions,
+// but when the unsuffixed path does not exist it probes the /vN major version
+// paths, as users often omit the suffix of v2+ modules.
+func getLatestMajor(ctx context.Context, moduleNames string) GetLatestMajorResult {
+	modules, invalid, err := normalizeModules(moduleNames)
+	if err != nil {
+		return fail[GetLatestMajorResult](err)
+	}
+	resolved := make([]*resolvedModule, len(modules))
+	failures := make([]error, len(modules))
+
+	forEachModule(ctx, modules, func(i int, moduleName string) {
+		resolved[i], failures[i] = resolveMajor(ctx, moduleName)
+	})
+
+	batch := newBatchResult(invalid)
+	for i, moduleName := range modules {
+		if failures[i] != nil {
+			batch.addError(moduleName, failures[i])
+			continue
+		}
+		batch.add(moduleName, resolved[i])
+	}
+	return batchOutput[GetLatestMajorResult](batch, "get latest major versions")
+}
+
+// resolveMajor returns the @latest version of moduleName, falling back to
+// the highest major version path when moduleName itself is not found.
+func resolveMajor(ctx context.Context, moduleName string) (*resolvedModule, error) {
+	version, err := resolveLatestVersion(ctx, moduleName)
+	if err == nil {
+		return &resolvedModule{Module: moduleName, Version: version}, nil
+	}
+	if !isNotFound(err) {
+		return nil, err
+	}
+
+	major, err := findLatestMajor(ctx, moduleName)
+	if err != nil {
+		return nil, err
+	}
+	return &resolvedModule{Module: major.Module, Version: major.LatestVersion}, nil
+}
+
+// majorRelease is the latest release of one major version series.
+type majorRelease struct {
+	Module  string    `json:"module"`
+	Version string    `json:"version"`
+	Time    time.Time `json:"time"`
+}
+
+// getLatestPerMajor reports the latest version of every major version series
+// of moduleName, keyed by "v1", "v2", and so on. Users on an older major
+// still want its newest backported release. Majors that do not exist are
+// omitted.
+func getLatestPerMajor(ctx context.Context, moduleName string) GetLatestPerMajorResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[GetLatestPerMajorResult](invalidInput("Module name must not be empty"))
+	}
+
+	paths := majorPaths(moduleName)
+	releases := make([]*majorRelease, len(paths))
+	failures := make([]error, len(paths))
+	var notFound error
+
+	forEachModuleLimit(ctx, paths, majorProbeWorkers, func(i int, path string) {
+		info, err := fetchLatestInfo(ctx, path)
+		if err != nil {
+			if !isNotFound(err) {
+				failures[i] = err
+			} else if i == 0 {
+				notFound = err
+			}
+			return
+		}
+		releases[i] = &majorRelease{Module: path, Version: info.Version, Time: info.Time}
+	})
+
+	results := make(map[string]*majorRelease)
+	for i, release := range releases {
+		if failures[i] != nil {
+			return fail[GetLatestPerMajorResult](fmt.Errorf("Failed to probe %s: %w", paths[i], failures[i]))
+		}
+		if release != nil {
+			results[semver.Major(release.Version)] = release
+		}
+	}
+
+	if len(results) == 0 {
+		return fail[GetLatestPerMajorResult](describe(notFound, "Module %s not found on the proxy at any major version up to v%d", moduleName, maxProbedMajor))
+	}
+
+	jsonData, err := json.Marshal(results)
+	if err != nil {
+		return fail[GetLatestPerMajorResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[GetLatestPerMajorResult](string(jsonData))
+}
+
+// fetchLatestInfo downloads the @latest record of moduleName.
+func fetchLatestInfo(ctx context.Context, moduleName string) (*versionInfo, error) {
+	data, err := proxyRequest(ctx, escapedLatestPath(moduleName))
+	if err != nil {
+		if isNotFound(err) {
+			return nil, describe(err, "module not found: %s", moduleName)
+		}
+		return nil, fmt.Errorf("transport failure: fetching %s@latest: %w", moduleName, err)
+	}
+
+	var info versionInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse @latest response for %s: %w", moduleName, err)
+	}
+	if info.Version == "" {
+		return nil, fmt.Errorf("failed to parse @latest response for %s: no version", moduleName)
+	}
+
+	return &info, nil
+}
@@ -0,0 +1,97 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestMajorPaths(t *testing.T) {
+	tests := []struct {
+		module string
+		want   []string
+	}{
+		{"github.com/foo/bar", []string{"github.com/foo/bar", "github.com/foo/bar/v2", "github.com/foo/bar/v3"}},
+		{"github.com/foo/bar/v4", []string{"github.com/foo/bar", "github.com/foo/bar/v2", "github.com/foo/bar/v3"}},
+		{"gopkg.in/yaml.v2", []string{"gopkg.in/yaml.v0", "gopkg.in/yaml.v1", "gopkg.in/yaml.v2"}},
+		{"gopkg.in/yaml", []string{"gopkg.in/yaml.v0", "gopkg.in/yaml.v1", "gopkg.in/yaml.v2"}},
+	}
+	for _, tt := range tests {
+		got := majorPaths(tt.module)
+		if len(got) < len(tt.want) || !slices.Equal(got[:len(tt.want)], tt.want) {
+			t.Errorf("majorPaths(%q) starts %q, want %q", tt.module, got[:min(len(got), len(tt.want))], tt.want)
+		}
+	}
+}
+
+// fakeLatest answers @latest requests from versions, keyed by module path,
+// with gone listing paths that answer 410 and every other path 404.
+func fakeLatest(t *testing.T, versions map[string]string, gone ...string) {
+	fakeTransport(t, func(req *http.Request) (*http.Response, error) {
+		path, ok := strings.CutSuffix(strings.TrimPrefix(req.URL.Path, "/"), "/@latest")
+		switch {
+		case !ok:
+			return textResponse(req, http.StatusNotFound, "not found"), nil
+		case slices.Contains(gone, path):
+			return textResponse(req, http.StatusGone, "gone"), nil
+		case versions[path] != "":
+			return textResponse(req, http.StatusOK, `{"Version":"`+versions[path]+`"}`), nil
+		}
+		return textResponse(req, http.StatusNotFound, "not found"), nil
+	})
+}
+
+func TestFindLatestMajor(t *testing.T) {
+	tests := []struct {
+		name     string
+		module   string
+		versions map[string]string
+		gone     []string
+		want     string
+	}{
+		{
+			name:     "skips missing and gone majors",
+			module:   "github.com/foo/bar",
+			versions: map[string]string{"github.com/foo/bar": "v1.4.0", "github.com/foo/bar/v2": "v2.1.0"},
+			gone:     []string{"github.com/foo/bar/v3"},
+			want:     "github.com/foo/bar/v2",
+		},
+		{
+			name:     "gopkg.in",
+			module:   "gopkg.in/yaml.v2",
+			versions: map[string]string{"gopkg.in/yaml.v1": "v1.0.0", "gopkg.in/yaml.v2": "v2.4.0", "gopkg.in/yaml.v3": "v3.0.1"},
+			want:     "gopkg.in/yaml.v3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeLatest(t, tt.versions, tt.gone...)
+			latest, err := findLatestMajor(context.Background(), tt.module)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if latest.Module != tt.want {
+				t.Errorf("latest major of %s is %s, want %s", tt.module, latest.Module, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchLatestInfoParseError(t *testing.T) {
+	fakeRoutes(t, map[string]string{
+		"proxy.test/example.com/m/@latest": `{"Version":`,
+	})
+
+	_, err := fetchLatestInfo(context.Background(), "example.com/m")
+	if err == nil {
+		t.Fatal("fetchLatestInfo accepted a truncated @latest response")
+	}
+	if got := classifyError(err); got != "parse_error" {
+		t.Errorf("classifyError(%v) = %q, want parse_error", err, got)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"go.bytecodealliance.org/cm"
+)
+
+type GetMetricsResult = cm.Result[string, string, string]
+
+// Counters of the traffic the component has handled since it was
+// instantiated. The host may reuse an instance across tool calls, so they
+// accumulate over the instance's lifetime.
+var (
+	requestCount atomic.Int64
+	cacheHits    atomic.Int64
+	cacheMisses  atomic.Int64
+	retryCount   atomic.Int64
+	// cacheEvictions counts responses dropped to keep the cache within
+	// maxCacheEntries.
+	cacheEvictions atomic.Int64
+	// transportErrors counts requests that failed without a response.
+	transportErrors atomic.Int64
+)
+
+// keyedCounters holds the counters broken down by HTTP status and by tool.
+var keyedCounters = struct {
+	sync.Mutex
+	statuses map[int]int64
+	tools    map[string]int64
+}{statuses: make(map[int]int64), tools: make(map[string]int64)}
+
+// countStatus records a response with the given status code.
+func countStatus(code int) {
+	keyedCounters.Lock()
+	defer keyedCounters.Unlock()
+	keyedCounters.statuses[code]++
+}
+
+// countInvocation records a call of the named tool.
+func countInvocation(tool string) {
+	keyedCounters.Lock()
+	defer keyedCounters.Unlock()
+	keyedCounters.tools[tool]++
+}
+
+// metricsSnapshot is the result of getMetrics.
+type metricsSnapshot struct {
+	Requests        int64            `json:"requests"`
+	CacheHits       int64            `json:"cache_hits"`
+	CacheMisses     int64            `json:"cache_misses"`
+	CacheEntries    int              `json:"cache_entries"`
+	CacheEvictions  int64            `json:"cache_evictions"`
+	Retries         int64            `json:"retries"`
+	TransportErrors int64            `json:"transport_errors"`
+	StatusCodes     map[int]int64    `json:"status_codes"`
+	ToolInvocations map[string]int64 `json:"tool_invocations"`
+}
+
+// getMetrics reports the request, cache, retry, status code and tool
+// invocation counters. Requests counts every request sent, including
+// retries; cache hits are not sent at all.
+func getMetrics(_ context.Context) GetMetricsResult {
+	snapshot := metricsSnapshot{
+		Requests:        requestCount.Load(),
+		CacheHits:       cacheHits.Load(),
+		CacheMisses:     cacheMisses.Load(),
+		CacheEntries:    cacheSize(),
+		CacheEvictions:  cacheEvictions.Load(),
+		Retries:         retryCount.Load(),
+		TransportErrors: transportErrors.Load(),
+		StatusCodes:     make(map[int]int64),
+		ToolInvocations: make(map[string]int64),
+	}
+
+	keyedCounters.Lock()
+	for code, count := range keyedCounters.statuses {
+		snapshot.StatusCodes[code] = count
+	}
+	for tool, count := range keyedCounters.tools {
+		snapshot.ToolInvocations[tool] = count
+	}
+	keyedCounters.Unlock()
+
+	jsonData, err := json.Marshal(snapshot)
+	if err != nil {
+		return fail[GetMetricsResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[GetMetricsResult](string(jsonData))
+}
@@ -0,0 +1,240 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"go.bytecodealliance.org/cm"
+	"golang.org/x/mod/module"
+)
+
+type NormalizeModulePathResult = cm.Result[string, string, string]
+type ValidateModulePathResult = cm.Result[string, string, string]
+
+// vcsHosts are the hosts whose module paths are often pasted as clone URLs,
+// with a trailing ".git" that is not part of the module path.
+var vcsHosts = map[string]bool{
+	"github.com":    true,
+	"gitlab.com":    true,
+	"bitbucket.org": true,
+}
+
+// normalizedPath reports how an input was turned into a module path.
+type normalizedPath struct {
+	Input          string   `json:"input"`
+	Path           string   `json:"path"`
+	EscapedPath    string   `json:"escaped_path"`
+	Normalized     bool     `json:"normalized"`
+	Normalizations []string `json:"normalizations"`
+}
+
+// escapeModulePath case-encodes moduleName for use in a proxy URL, so that
+// "github.com/Azure/azure-sdk-for-go" becomes "github.com/!azure/azure-sdk-for-go".
+// Paths module.EscapePath rejects are returned unchanged; the proxy then
+// reports them as not found.
+func escapeModulePath(moduleName string) string {
+	escapedPath, err := module.EscapePath(moduleName)
+	if err != nil {
+		return moduleName
+	}
+	return escapedPath
+}
+
+// canonicalModulePath is defaultModulePath that also lists what it changed:
+// "default host", "trailing slash", ".git suffix" and "case". Only the host
+// is lowercased; the rest of a module path is case-sensitive.
+func canonicalModulePath(input string) (string, []string) {
+	normalizations := []string{}
+	moduleName := strings.TrimSpace(input)
+	if moduleName == "" {
+		return "", normalizations
+	}
+
+	if trimmed := strings.TrimRight(moduleName, "/"); trimmed != moduleName {
+		moduleName = trimmed
+		normalizations = append(normalizations, "trailing slash")
+	}
+
+	host, rest, hasRest := strings.Cut(moduleName, "/")
+	if !strings.Contains(host, ".") {
+		host, rest, hasRest = "github.com", moduleName, true
+		normalizations = append(normalizations, "default host")
+	} else if lower := strings.ToLower(host); lower != host {
+		host = lower
+		normalizations = append(normalizations, "case")
+	}
+
+	if hasRest && vcsHosts[host] && strings.HasSuffix(rest, ".git") {
+		rest = strings.TrimSuffix(rest, ".git")
+		normalizations = append(normalizations, ".git suffix")
+	}
+
+	if !hasRest {
+		return host, normalizations
+	}
+	return host + "/" + rest, normalizations
+}
+
+// normalizeModulePath reports the canonical module path of input and the
+// case-encoded form the proxy expects, without making any request.
+func normalizeModulePath(_ context.Context, input string) NormalizeModulePathResult {
+	moduleName, normalizations := canonicalModulePath(input)
+	if moduleName == "" {
+		return fail[NormalizeModulePathResult](invalidInput("Module name must not be empty"))
+	}
+
+	if err := module.CheckPath(moduleName); err != nil {
+		return fail[NormalizeModulePathResult](invalidInput("invalid module path: %v", unwrapModuleError(err)))
+	}
+
+	jsonData, err := json.Marshal(normalizedPath{
+		Input:          input,
+		Path:           moduleName,
+		EscapedPath:    escapeModulePath(moduleName),
+		Normalized:     len(normalizations) > 0,
+		Normalizations: normalizations,
+	})
+	if err != nil {
+		return fail[NormalizeModulePathResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[NormalizeModulePathResult](string(jsonData))
+}
+
+// windowsReservedNames may not appear before the first dot of a path element,
+// in any case, as Windows cannot create files with those names.
+var windowsReservedNames = []string{
+	"CON", "PRN", "AUX", "NUL",
+	"COM1", "COM2", "COM3", "COM4", "COM5", "COM6", "COM7", "COM8", "COM9",
+	"LPT1", "LPT2", "LPT3", "LPT4", "LPT5", "LPT6", "LPT7", "LPT8", "LPT9",
+}
+
+// pathViolation is one rule a module path breaks.
+type pathViolation struct {
+	Rule    string `json:"rule"`
+	Element string `json:"element,omitempty"`
+	Message string `json:"message"`
+}
+
+// pathValidation is the result of validate-module-path. EscapedPath is only
+// set for valid paths.
+type pathValidation struct {
+	Path        string          `json:"path"`
+	Valid       bool            `json:"valid"`
+	Violations  []pathViolation `json:"violations"`
+	EscapedPath string          `json:"escaped_path,omitempty"`
+}
+
+// modulePathViolations lists every rule of module.CheckPath that moduleName
+// breaks, where CheckPath itself only reports the first.
+func modulePathViolations(moduleName string) []pathViolation {
+	violations := []pathViolation{}
+	add := func(rule, element, format string, args ...interface{}) {
+		violations = append(violations, pathViolation{Rule: rule, Element: element, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if !utf8.ValidString(moduleName) {
+		add("invalid_utf8", "", "path is not valid UTF-8")
+		return violations
+	}
+	if strings.HasPrefix(moduleName, "/") {
+		add("leading_slash", "", "path must not start with a slash")
+	}
+	if strings.HasSuffix(moduleName, "/") {
+		add("trailing_slash", "", "path must not end with a slash")
+	}
+	if strings.Contains(moduleName, "//") {
+		add("empty_element", "", "path must not contain a double slash")
+	}
+
+	elements := strings.Split(strings.Trim(moduleName, "/"), "/")
+	host := elements[0]
+	if !strings.Contains(host, ".") {
+		add("host_missing_dot", host, "first path element %q must contain a dot, like example.com", host)
+	}
+	if strings.HasPrefix(host, "-") {
+		add("leading_dash", host, "first path element %q must not start with a dash", host)
+	}
+	for _, r := range host {
+		if !('a' <= r && r <= 'z' || '0' <= r && r <= '9' || r == '.' || r == '-') {
+			add("host_character", host, "first path element %q may only contain lowercase letters, digits, dots and dashes, not %q", host, r)
+			break
+		}
+	}
+
+	for _, element := range elements {
+		if element == "" {
+			continue
+		}
+		for _, r := range element {
+			if !modulePathRuneOK(r) {
+				add("invalid_character", element, "path element %q contains %q; only ASCII letters, digits and -._~ are allowed", element, r)
+				break
+			}
+		}
+		if strings.HasPrefix(element, ".") {
+			add("leading_dot", element, "path element %q must not start with a dot", element)
+		}
+		if strings.HasSuffix(element, ".") {
+			add("trailing_dot", element, "path element %q must not end with a dot", element)
+		}
+		short, _, _ := strings.Cut(element, ".")
+		for _, reserved := range windowsReservedNames {
+			if strings.EqualFold(short, reserved) {
+				add("windows_reserved_name", element, "path element %q uses the name %s, which is reserved on Windows", element, reserved)
+				break
+			}
+		}
+	}
+
+	if _, _, ok := module.SplitPathVersion(moduleName); !ok {
+		if strings.HasPrefix(moduleName, "gopkg.in/") {
+			add("major_version_suffix", "", "gopkg.in paths must end in a .vN major version suffix, like gopkg.in/yaml.v3")
+		} else {
+			add("major_version_suffix", elements[len(elements)-1], "major version suffix must be /vN with N >= 2 and no leading zero")
+		}
+	}
+
+	if len(violations) == 0 {
+		if err := module.CheckPath(moduleName); err != nil {
+			add("invalid_path", "", "%v", unwrapModuleError(err))
+		}
+	}
+	return violations
+}
+
+// modulePathRuneOK reports whether r may appear in a module path element.
+func modulePathRuneOK(r rune) bool {
+	return 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' ||
+		r == '-' || r == '.' || r == '_' || r == '~'
+}
+
+// validateModulePath checks path against the module path rules without
+// contacting the proxy, so that a malformed path is reported as such rather
+// than as a 404. Unlike the other tools it does not apply defaultModulePath:
+// the path is validated exactly as given, apart from surrounding whitespace.
+func validateModulePath(_ context.Context, path string) ValidateModulePathResult {
+	moduleName := strings.TrimSpace(path)
+	if moduleName == "" {
+		return fail[ValidateModulePathResult](invalidInput("Module name must not be empty"))
+	}
+
+	result := pathValidation{Path: moduleName, Violations: modulePathViolations(moduleName)}
+	result.Valid = len(result.Violations) == 0
+	if result.Valid {
+		result.EscapedPath = escapeModulePath(moduleName)
+	}
+
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		return fail[ValidateModulePathResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[ValidateModulePathResult](string(jsonData))
+}
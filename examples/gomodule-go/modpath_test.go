@@ -0,0 +1,25 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import "testing"
+
+func TestEscapeModulePath(t *testing.T) {
+	tests := []struct {
+		module string
+		want   string
+	}{
+		{"github.com/gorilla/mux", "github.com/gorilla/mux"},
+		{"github.com/Azure/azure-sdk-for-go", "github.com/!azure/azure-sdk-for-go"},
+		{"github.com/BurntSushi/toml", "github.com/!burnt!sushi/toml"},
+		{"gopkg.in/yaml.v3", "gopkg.in/yaml.v3"},
+		// Paths module.EscapePath rejects are left for the proxy to refuse.
+		{"github.com/foo bar", "github.com/foo bar"},
+	}
+	for _, tt := range tests {
+		if got := escapeModulePath(tt.module); got != tt.want {
+			t.Errorf("escapeModulePath(%q) = %q, want %q", tt.module, got, tt.want)
+		}
+	}
+}
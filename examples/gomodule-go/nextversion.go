@@ -0,0 +1,133 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.bytecodealliance.org/cm"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+type SuggestNextVersionResult = cm.Result[string, string, string]
+
+// bumpKinds are the bumps suggestNextVersion accepts, in the order of the
+// version components they increment.
+var bumpKinds = []string{"major", "minor", "patch"}
+
+// versionCandidate is a candidate release. ModulePath differs from the current
+// module path, and PathChange is set, when a major bump to v2 or later
+// requires a new /vN module path.
+type versionCandidate struct {
+	Version    string `json:"version"`
+	ModulePath string `json:"module_path"`
+	PathChange bool   `json:"path_change"`
+}
+
+// nextVersions is the result of suggestNextVersion.
+type nextVersions struct {
+	Module           string                       `json:"module"`
+	Current          string                       `json:"current,omitempty"`
+	LatestPrerelease string                       `json:"latest_prerelease,omitempty"`
+	Next             map[string]*versionCandidate `json:"next"`
+}
+
+// suggestNextVersion suggests the version the next release of moduleName
+// should be tagged with, for bump "major", "minor" or "patch", or for all
+// three when bump is empty. It counts from the highest stable tag; a newer
+// prerelease of the same bump, such as v1.2.3-rc.1 after v1.2.2, is
+// collapsed into its release.
+func suggestNextVersion(ctx context.Context, moduleName, bump string) SuggestNextVersionResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[SuggestNextVersionResult](invalidInput("Module name must not be empty"))
+	}
+
+	kinds := bumpKinds
+	if bump = strings.ToLower(strings.TrimSpace(bump)); bump != "" {
+		if !containsString(bumpKinds, bump) {
+			return fail[SuggestNextVersionResult](invalidInput("Invalid bump %q: expected major, minor or patch", bump))
+		}
+		kinds = []string{bump}
+	}
+
+	data, err := proxyRequest(ctx, escapedListPath(moduleName))
+	if err != nil {
+		if isNotFound(err) {
+			return fail[SuggestNextVersionResult](fmt.Errorf("Module %s not found on the proxy: %w", moduleName, err))
+		}
+		return fail[SuggestNextVersionResult](fmt.Errorf("Failed to fetch %s: %w", moduleName, err))
+	}
+
+	var tags []string
+	for _, v := range parseVersionList(data) {
+		if semver.Build(v) == "" {
+			tags = append(tags, v)
+		}
+	}
+	current, _ := latestStable(tags)
+	prerelease, _ := latestPrerelease(tags)
+
+	result := nextVersions{Module: moduleName, Current: current, Next: make(map[string]*versionCandidate)}
+	base := current
+	if base == "" {
+		// Before the first release, count from v0.0.0.
+		base = "v0.0.0"
+	}
+	if prerelease != "" && semver.Compare(prerelease, base) > 0 {
+		result.LatestPrerelease = prerelease
+	}
+
+	for _, kind := range kinds {
+		next := nextVersion(base, slices.Index(bumpKinds, kind)+1)
+		if result.LatestPrerelease != "" {
+			if release := releaseVersion(prerelease); bumpKind(base, release) == kind && semver.Compare(release, next) > 0 {
+				next = release
+			}
+		}
+		result.Next[kind] = &versionCandidate{Version: next, ModulePath: majorModulePath(moduleName, next)}
+		result.Next[kind].PathChange = result.Next[kind].ModulePath != moduleName
+	}
+
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		return fail[SuggestNextVersionResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[SuggestNextVersionResult](string(jsonData))
+}
+
+// majorModulePath is the module path moduleName must have to publish
+// version: unsuffixed for v0 and v1, and with a /vN suffix from v2 on.
+func majorModulePath(moduleName, version string) string {
+	prefix, _, ok := module.SplitPathVersion(moduleName)
+	if !ok {
+		prefix = moduleName
+	}
+
+	major := semver.Major(version)
+	switch {
+	case strings.HasPrefix(prefix, "gopkg.in/"):
+		// gopkg.in paths always carry their major version.
+		return prefix + "." + major
+	case major == "v0" || major == "v1":
+		return prefix
+	default:
+		return prefix + "/" + major
+	}
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"go.bytecodealliance.org/cm"
+)
+
+type GetModuleOriginResult = cm.Result[string, string, string]
+
+// vcsOrigin is the Origin block of a proxy .info response.
+type vcsOrigin struct {
+	VCS    string
+	URL    string
+	Subdir string
+	Ref    string
+	Hash   string
+}
+
+// moduleOrigin reports which repository and commit a module version was
+// built from.
+type moduleOrigin struct {
+	Module            string `json:"module"`
+	Version           string `json:"version"`
+	VCS               string `json:"vcs,omitempty"`
+	URL               string `json:"url,omitempty"`
+	Subdir            string `json:"subdir,omitempty"`
+	Ref               string `json:"ref,omitempty"`
+	Hash              string `json:"hash,omitempty"`
+	OriginUnavailable bool   `json:"origin_unavailable"`
+}
+
+// getModuleOrigin reports the VCS origin of the latest version of
+// moduleName. Records cached before the proxy started recording origins
+// are reported with origin_unavailable set.
+func getModuleOrigin(ctx context.Context, moduleName string) GetModuleOriginResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[GetModuleOriginResult](invalidInput("Module name must not be empty"))
+	}
+
+	version, origin, err := fetchOrigin(ctx, moduleName)
+	if err != nil {
+		return fail[GetModuleOriginResult](err)
+	}
+
+	result := moduleOrigin{Module: moduleName, Version: version, OriginUnavailable: true}
+	if origin != nil {
+		result.VCS = origin.VCS
+		result.URL = origin.URL
+		result.Subdir = origin.Subdir
+		result.Ref = origin.Ref
+		result.Hash = origin.Hash
+		result.OriginUnavailable = false
+	}
+
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		return fail[GetModuleOriginResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[GetModuleOriginResult](string(jsonData))
+}
+
+// fetchOrigin returns the latest version of moduleName and its validated VCS
+// origin, with the hash in lower case. The origin is nil when the proxy did
+// not record one.
+func fetchOrigin(ctx context.Context, moduleName string) (string, *vcsOrigin, error) {
+	version, err := resolveLatestVersion(ctx, moduleName)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// @latest responses usually leave Origin out; the .info record keeps it.
+	info, err := fetchVersionInfo(ctx, moduleName, version)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(info.Origin) == 0 || string(info.Origin) == "null" {
+		return info.Version, nil, nil
+	}
+
+	var origin vcsOrigin
+	if err := json.Unmarshal(info.Origin, &origin); err != nil {
+		return "", nil, fmt.Errorf("failed to parse origin for %s@%s: %w", moduleName, info.Version, err)
+	}
+
+	if origin.URL != "" {
+		if parsed, err := url.Parse(origin.URL); err != nil || parsed.Scheme != "https" {
+			return "", nil, fmt.Errorf("origin URL %q for %s@%s is not an https URL", origin.URL, moduleName, info.Version)
+		}
+	}
+
+	origin.Hash = strings.ToLower(origin.Hash)
+	if _, err := hex.DecodeString(origin.Hash); err != nil {
+		return "", nil, fmt.Errorf("origin hash %q for %s@%s is not hexadecimal", origin.Hash, moduleName, info.Version)
+	}
+
+	return info.Version, &origin, nil
+}
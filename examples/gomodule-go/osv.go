@@ -0,0 +1,246 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"go.bytecodealliance.org/cm"
+)
+
+type CheckVulnerabilitiesResult = cm.Result[string, string, string]
+type GetModuleVulnerabilitiesResult = cm.Result[string, string, string]
+
+// osvQueryURL is the OSV.dev endpoint for querying a single package version.
+const osvQueryURL = "https://api.osv.dev/v1/query"
+
+// osvQuery is the request body of an OSV.dev query.
+type osvQuery struct {
+	Package struct {
+		Name      string `json:"name"`
+		Ecosystem string `json:"ecosystem"`
+	} `json:"package"`
+	Version string `json:"version"`
+}
+
+// vulnerability is the summary of an OSV advisory returned to callers.
+type vulnerability struct {
+	ID      string `json:"id"`
+	Summary string `json:"summary"`
+}
+
+// osvSeverity is a severity score of an OSV advisory, e.g. a CVSS vector.
+type osvSeverity struct {
+	Type  string `json:"type"`
+	Score string `json:"score"`
+}
+
+// osvVulnerability is the subset of an OSV advisory the tools report.
+type osvVulnerability struct {
+	ID       string        `json:"id"`
+	Summary  string        `json:"summary"`
+	Aliases  []string      `json:"aliases"`
+	Severity []osvSeverity `json:"severity"`
+	Affected []struct {
+		Package struct {
+			Name string `json:"name"`
+		} `json:"package"`
+		Ranges []struct {
+			Type   string              `json:"type"`
+			Events []map[string]string `json:"events"`
+		} `json:"ranges"`
+	} `json:"affected"`
+}
+
+// affectedRange is a span of affected versions. Fixed is the first version
+// without the vulnerability; it is empty when no fix has been released.
+type affectedRange struct {
+	Introduced   string `json:"introduced"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+}
+
+// advisory is an OSV advisory trimmed to what callers need to act on it.
+type advisory struct {
+	ID             string          `json:"id"`
+	Summary        string          `json:"summary"`
+	Aliases        []string        `json:"aliases,omitempty"`
+	Severity       []osvSeverity   `json:"severity"`
+	AffectedRanges []affectedRange `json:"affected_ranges"`
+	FixedIn        []string        `json:"fixed_in"`
+}
+
+// moduleVulnerabilities lists the advisories affecting a module version.
+type moduleVulnerabilities struct {
+	Version         string          `json:"version"`
+	Vulnerabilities []vulnerability `json:"vulnerabilities"`
+}
+
+// queryOSV asks OSV.dev for the advisories affecting moduleName at version.
+func queryOSV(ctx context.Context, moduleName, version string) ([]osvVulnerability, error) {
+	if err := checkPublic(moduleName); err != nil {
+		return nil, err
+	}
+
+	var query osvQuery
+	query.Package.Name = moduleName
+	query.Package.Ecosystem = "Go"
+	// OSV records Go versions without the leading "v".
+	query.Version = strings.TrimPrefix(version, "v")
+
+	data, err := httpPostJSON(ctx, osvQueryURL, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query OSV for %s@%s: %w", moduleName, version, err)
+	}
+
+	var response struct {
+		Vulns []osvVulnerability `json:"vulns"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse OSV response for %s@%s: %w", moduleName, version, err)
+	}
+
+	return response.Vulns, nil
+}
+
+// trimAdvisory keeps the ranges of vuln that apply to moduleName. OSV
+// records Go versions without the leading "v"; it is added back so the
+// versions can be used with the go command.
+func trimAdvisory(vuln osvVulnerability, moduleName string) advisory {
+	result := advisory{
+		ID:             vuln.ID,
+		Summary:        vuln.Summary,
+		Aliases:        vuln.Aliases,
+		Severity:       vuln.Severity,
+		AffectedRanges: []affectedRange{},
+		FixedIn:        []string{},
+	}
+	if result.Severity == nil {
+		result.Severity = []osvSeverity{}
+	}
+
+	for _, affected := range vuln.Affected {
+		if affected.Package.Name != moduleName {
+			continue
+		}
+		for _, r := range affected.Ranges {
+			if r.Type != "SEMVER" {
+				continue
+			}
+
+			var current *affectedRange
+			for _, event := range r.Events {
+				if introduced, ok := event["introduced"]; ok {
+					result.AffectedRanges = append(result.AffectedRanges, affectedRange{Introduced: osvVersion(introduced)})
+					current = &result.AffectedRanges[len(result.AffectedRanges)-1]
+					continue
+				}
+				if current == nil {
+					continue
+				}
+				if fixed, ok := event["fixed"]; ok {
+					current.Fixed = osvVersion(fixed)
+					result.FixedIn = append(result.FixedIn, current.Fixed)
+				} else if last, ok := event["last_affected"]; ok {
+					current.LastAffected = osvVersion(last)
+				}
+				current = nil
+			}
+		}
+	}
+	return result
+}
+
+// osvVersion converts an OSV SEMVER event to a Go version. "0" stands for
+// "every version" and is kept as is.
+func osvVersion(v string) string {
+	if v == "0" {
+		return v
+	}
+	return canonicalVersion(v)
+}
+
+// getModuleVulnerabilities reports the OSV advisories affecting moduleName
+// at version, resolving @latest first when version is empty. No advisories
+// is a successful, empty result.
+func getModuleVulnerabilities(ctx context.Context, moduleName, version string) GetModuleVulnerabilitiesResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[GetModuleVulnerabilitiesResult](invalidInput("Module name must not be empty"))
+	}
+
+	if version == "" {
+		latest, err := resolveLatestVersion(ctx, moduleName)
+		if err != nil {
+			return fail[GetModuleVulnerabilitiesResult](err)
+		}
+		version = latest
+	}
+
+	vulns, err := queryOSV(ctx, moduleName, version)
+	if err != nil {
+		return fail[GetModuleVulnerabilitiesResult](err)
+	}
+
+	advisories := []advisory{}
+	for _, vuln := range vulns {
+		advisories = append(advisories, trimAdvisory(vuln, moduleName))
+	}
+
+	jsonData, err := json.Marshal(struct {
+		Module          string     `json:"module"`
+		Version         string     `json:"version"`
+		Vulnerabilities []advisory `json:"vulnerabilities"`
+	}{moduleName, version, advisories})
+	if err != nil {
+		return fail[GetModuleVulnerabilitiesResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[GetModuleVulnerabilitiesResult](string(jsonData))
+}
+
+// checkVulnerabilities resolves the latest version of every module and
+// reports the OSV advisories affecting it.
+func checkVulnerabilities(ctx context.Context, moduleNames string) CheckVulnerabilitiesResult {
+	modules, invalid, err := normalizeModules(moduleNames)
+	if err != nil {
+		return fail[CheckVulnerabilitiesResult](err)
+	}
+	reports := make([]*moduleVulnerabilities, len(modules))
+	failures := make([]error, len(modules))
+
+	forEachModule(ctx, modules, func(i int, moduleName string) {
+		version, err := resolveLatestVersion(ctx, moduleName)
+		if err != nil {
+			failures[i] = err
+			return
+		}
+
+		vulns, err := queryOSV(ctx, moduleName, version)
+		if err != nil {
+			failures[i] = err
+			return
+		}
+
+		// OSV omits "vulns" entirely when nothing matches.
+		summaries := []vulnerability{}
+		for _, vuln := range vulns {
+			summaries = append(summaries, vulnerability{ID: vuln.ID, Summary: vuln.Summary})
+		}
+		reports[i] = &moduleVulnerabilities{Version: version, Vulnerabilities: summaries}
+	})
+
+	batch := newBatchResult(invalid)
+	for i, moduleName := range modules {
+		if failures[i] != nil {
+			batch.addError(moduleName, failures[i])
+			continue
+		}
+		batch.add(moduleName, reports[i])
+	}
+	return batchOutput[CheckVulnerabilitiesResult](batch, "check vulnerabilities")
+}
@@ -0,0 +1,90 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"go.bytecodealliance.org/cm"
+	"golang.org/x/mod/module"
+)
+
+type ResolvePackageToModuleResult = cm.Result[string, string, string]
+
+// packageModule is the module that provides an import path. Package is the
+// import path relative to the module root, "." for the root package.
+type packageModule struct {
+	ImportPath string   `json:"import_path"`
+	Module     string   `json:"module"`
+	Version    string   `json:"version"`
+	Package    string   `json:"package"`
+	Tried      []string `json:"tried"`
+}
+
+// modulePrefixes lists the prefixes of importPath that could be its module
+// path, longest first.
+func modulePrefixes(importPath string) []string {
+	elems := strings.Split(importPath, "/")
+	prefixes := make([]string, 0, len(elems))
+	for i := len(elems); i > 0; i-- {
+		prefixes = append(prefixes, strings.Join(elems[:i], "/"))
+	}
+	return prefixes
+}
+
+// resolvePackageToModule finds the module providing importPath the way the
+// go command does: each prefix is probed at @latest, longest first, and the
+// first one the proxy serves wins. The zip is not downloaded, so a module
+// that exists but no longer contains the package is still reported.
+func resolvePackageToModule(ctx context.Context, importPath string) ResolvePackageToModuleResult {
+	importPath = defaultModulePath(importPath)
+	if importPath == "" {
+		return fail[ResolvePackageToModuleResult](invalidInput("Import path must not be empty"))
+	}
+
+	if err := module.CheckImportPath(importPath); err != nil {
+		return fail[ResolvePackageToModuleResult](invalidInput("invalid import path: %v", unwrapModuleError(err)))
+	}
+
+	tried := []string{}
+	var notFound error
+	for _, prefix := range modulePrefixes(importPath) {
+		if module.CheckPath(prefix) != nil {
+			continue
+		}
+		tried = append(tried, prefix)
+
+		info, err := fetchLatestInfo(ctx, prefix)
+		if err != nil {
+			if isNotFound(err) {
+				notFound = err
+				continue
+			}
+			return fail[ResolvePackageToModuleResult](fmt.Errorf("Failed to probe %s: %w", prefix, err))
+		}
+
+		pkg := strings.TrimPrefix(strings.TrimPrefix(importPath, prefix), "/")
+		if pkg == "" {
+			pkg = "."
+		}
+
+		jsonData, err := json.Marshal(packageModule{
+			ImportPath: importPath,
+			Module:     prefix,
+			Version:    info.Version,
+			Package:    pkg,
+			Tried:      tried,
+		})
+		if err != nil {
+			return fail[ResolvePackageToModuleResult](fmt.Errorf("Failed to marshal results: %w", err))
+		}
+
+		return cm.OK[ResolvePackageToModuleResult](string(jsonData))
+	}
+
+	return fail[ResolvePackageToModuleResult](describe(notFound, "No module provides package %s; tried %s", importPath, strings.Join(tried, ", ")))
+}
@@ -0,0 +1,50 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.bytecodealliance.org/cm"
+)
+
+type PingResult = cm.Result[string, string, string]
+
+// pingModule is a small, long-lived module whose @latest every public proxy
+// can serve.
+const pingModule = "golang.org/x/text"
+
+// pingStatus is the result of ping.
+type pingStatus struct {
+	URL       string `json:"url"`
+	LatencyMS int64  `json:"latency_ms"`
+}
+
+// ping fetches @latest of pingModule through the configured proxies,
+// bypassing the response cache, and reports how long the request took.
+func ping(ctx context.Context) PingResult {
+	var status pingStatus
+	_, err := proxyFetch(escapedLatestPath(pingModule), func(url string) ([]byte, error) {
+		start := time.Now()
+		resp, err := sendRequest(ctx, url, requestOptions{})
+		status = pingStatus{URL: url, LatencyMS: time.Since(start).Milliseconds()}
+		if err != nil {
+			return nil, err
+		}
+		return resp.body, nil
+	})
+	if err != nil {
+		return fail[PingResult](fmt.Errorf("Proxy unreachable: %w", err))
+	}
+
+	jsonData, err := json.Marshal(status)
+	if err != nil {
+		return fail[PingResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[PingResult](string(jsonData))
+}
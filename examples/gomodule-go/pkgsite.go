@@ -0,0 +1,117 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.bytecodealliance.org/cm"
+)
+
+type GetPackageDetailsResult = cm.Result[string, string, string]
+
+// pkgsiteURL is the Go package discovery site. It has no JSON API, so the
+// details are read from the module's HTML page.
+const pkgsiteURL = "https://pkg.go.dev"
+
+var (
+	pkgsiteSynopsis   = regexp.MustCompile(`<meta name="[Dd]escription" content="([^"]*)"`)
+	pkgsiteLicense    = regexp.MustCompile(`data-test-id="UnitHeader-license"[^>]*>\s*([^<]+?)\s*<`)
+	pkgsiteImportedBy = regexp.MustCompile(`Imported by:\s*([\d,]+)`)
+)
+
+// packageDetails combines the proxy's @latest record with what pkg.go.dev
+// knows about a module. The pkg.go.dev fields are omitted when it has no
+// data; PkgsiteError then says why.
+type packageDetails struct {
+	Version      string `json:"version"`
+	Time         string `json:"time"`
+	Synopsis     string `json:"synopsis,omitempty"`
+	License      string `json:"license,omitempty"`
+	ImportedBy   *int   `json:"imported_by,omitempty"`
+	PkgsiteError string `json:"pkgsite_error,omitempty"`
+}
+
+// getPackageDetails is getModuleInfo enriched with the synopsis, license and
+// imported-by count shown on pkg.go.dev. It makes a second HTTP request per
+// module, with the same retries and timeout as proxy requests.
+func getPackageDetails(ctx context.Context, moduleNames string) GetPackageDetailsResult {
+	modules, invalid, err := normalizeModules(moduleNames)
+	if err != nil {
+		return fail[GetPackageDetailsResult](err)
+	}
+	details := make([]*packageDetails, len(modules))
+	failures := make([]error, len(modules))
+
+	for i, fetched := range fetchModules(ctx, modules, escapedLatestPath) {
+		var info struct {
+			Version string
+			Time    string
+		}
+
+		err := fetched.err
+		if err == nil {
+			err = json.Unmarshal(fetched.data, &info)
+		}
+		if err != nil {
+			failures[i] = err
+			continue
+		}
+		details[i] = &packageDetails{Version: info.Version, Time: info.Time}
+	}
+
+	forEachModule(ctx, modules, func(i int, moduleName string) {
+		if details[i] != nil {
+			if err := addPkgsiteDetails(ctx, details[i], moduleName); err != nil {
+				details[i].PkgsiteError = err.Error()
+			}
+		}
+	})
+
+	batch := newBatchResult(invalid)
+	for i, moduleName := range modules {
+		if failures[i] != nil {
+			batch.addError(moduleName, failures[i])
+			continue
+		}
+		batch.add(moduleName, details[i])
+	}
+	return batchOutput[GetPackageDetailsResult](batch, "get package details")
+}
+
+// addPkgsiteDetails fills in the pkg.go.dev fields of details from the page
+// of moduleName at the version the proxy reported.
+func addPkgsiteDetails(ctx context.Context, details *packageDetails, moduleName string) error {
+	if err := checkPublic(moduleName); err != nil {
+		return err
+	}
+
+	data, err := httpRequest(ctx, fmt.Sprintf("%s/%s@%s", pkgsiteURL, moduleName, details.Version))
+	if err != nil {
+		if isNotFound(err) {
+			return fmt.Errorf("pkg.go.dev has no page for %s@%s", moduleName, details.Version)
+		}
+		return fmt.Errorf("failed to fetch pkg.go.dev page: %w", err)
+	}
+	page := string(data)
+
+	if match := pkgsiteSynopsis.FindStringSubmatch(page); match != nil {
+		details.Synopsis = html.UnescapeString(match[1])
+	}
+	if match := pkgsiteLicense.FindStringSubmatch(page); match != nil {
+		details.License = html.UnescapeString(match[1])
+	}
+	if match := pkgsiteImportedBy.FindStringSubmatch(page); match != nil {
+		if count, err := strconv.Atoi(strings.ReplaceAll(match[1], ",", "")); err == nil {
+			details.ImportedBy = &count
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/mod/module"
+)
+
+// privatePatterns holds the module path globs read from the environment when
+// the component starts, using the go command's defaults: GONOPROXY and
+// GONOSUMDB fall back to GOPRIVATE.
+var privatePatterns struct {
+	private  string
+	noProxy  string
+	noSumDB  string
+	sumDBOff bool
+}
+
+func init() {
+	privatePatterns.private = os.Getenv("GOPRIVATE")
+	privatePatterns.noProxy = envOr("GONOPROXY", privatePatterns.private)
+	privatePatterns.noSumDB = strings.Trim(envOr("GONOSUMDB", privatePatterns.private)+","+os.Getenv("GONOSUMCHECK"), ",")
+	privatePatterns.sumDBOff = os.Getenv("GOSUMDB") == "off"
+}
+
+// envOr returns the value of the environment variable key, or fallback when
+// it is unset. As with the go command, an empty value still overrides.
+func envOr(key, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok {
+		return value
+	}
+	return fallback
+}
+
+// privateModuleError is returned instead of contacting a public service
+// about a module that matches one of the private patterns.
+type privateModuleError struct {
+	module   string
+	variable string
+}
+
+func (e *privateModuleError) Error() string {
+	return fmt.Sprintf("skipped private module %s: it matches %s, so it is not sent to public services", e.module, e.variable)
+}
+
+// checkPublic returns a privateModuleError if moduleName matches GOPRIVATE,
+// for services such as OSV.dev that have no dedicated exclusion variable.
+func checkPublic(moduleName string) error {
+	if module.MatchPrefixPatterns(privatePatterns.private, moduleName) {
+		return &privateModuleError{module: moduleName, variable: "GOPRIVATE"}
+	}
+	return nil
+}
+
+// checkProxyAllowed returns a privateModuleError if moduleName must not be
+// fetched through a module proxy. The go command would fetch such modules
+// directly from version control, which the component cannot do.
+func checkProxyAllowed(moduleName string) error {
+	if module.MatchPrefixPatterns(privatePatterns.noProxy, moduleName) {
+		return &privateModuleError{module: moduleName, variable: "GONOPROXY/GOPRIVATE"}
+	}
+	return nil
+}
+
+// sumDBDisabledFor reports which environment variable, if any, excludes
+// moduleName from checksum database verification.
+func sumDBDisabledFor(moduleName string) string {
+	if privatePatterns.sumDBOff {
+		return "GOSUMDB=off"
+	}
+	if module.MatchPrefixPatterns(privatePatterns.noSumDB, moduleName) {
+		return "GONOSUMDB/GONOSUMCHECK/GOPRIVATE"
+	}
+	return ""
+}
+
+// proxyPathModule extracts the module path from a proxy request path such as
+// "github.com/!azure/sdk/@v/list".
+func proxyPathModule(path string) string {
+	escapedPath, _, _ := strings.Cut(path, "/@")
+	if modulePath, err := module.UnescapePath(escapedPath); err == nil {
+		return modulePath
+	}
+	return escapedPath
+}
@@ -0,0 +1,255 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"golang.org/x/mod/module"
+)
+
+// defaultGoProxy is used when GOPROXY is unset or empty.
+const defaultGoProxy = "https://proxy.golang.org"
+
+// proxyEntry is a single element of a GOPROXY list.
+type proxyEntry struct {
+	// base is a proxy URL without a trailing slash, or "direct" or "off".
+	base string
+	// fallbackOnError is true when the entry was followed by "|", meaning
+	// the next entry is tried after any error rather than only after 404/410.
+	fallbackOnError bool
+}
+
+// proxies holds the parsed GOPROXY list, read once when the component starts.
+var proxies []proxyEntry
+
+// raceProxies is set by GOMODULE_PROXY_RACE=1: proxyRequest then asks every
+// proxy at once and takes the first answer, instead of trying them in turn.
+var raceProxies = os.Getenv("GOMODULE_PROXY_RACE") == "1"
+
+// proxyConfigError is why GOMODULE_PROXY was rejected. While it is set every
+// proxy request fails with it, rather than silently using another proxy.
+var proxyConfigError error
+
+func init() {
+	proxies = parseGoProxy(os.Getenv("GOPROXY"))
+	if value := strings.TrimSpace(os.Getenv("GOMODULE_PROXY")); value != "" {
+		base, err := parseProxyURL(value)
+		if err != nil {
+			proxyConfigError = err
+			return
+		}
+		proxies = []proxyEntry{{base: base}}
+	}
+}
+
+// parseProxyURL validates GOMODULE_PROXY, a single proxy base URL such as
+// "https://athens.example.com/", and returns it without trailing slashes.
+// A plain http:// URL is only accepted with insecure set, as every request
+// to it would be refused otherwise.
+func parseProxyURL(value string) (string, error) {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return "", invalidInput("invalid GOMODULE_PROXY %q: %v", value, err)
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", invalidInput("invalid GOMODULE_PROXY %q: must be an absolute http or https URL", value)
+	}
+	if parsed.Scheme == "http" && !insecure {
+		return "", invalidInput("invalid GOMODULE_PROXY %q: http URLs need GOMODULE_INSECURE=1", value)
+	}
+	if parsed.RawQuery != "" || parsed.Fragment != "" {
+		return "", invalidInput("invalid GOMODULE_PROXY %q: must not have a query or fragment", value)
+	}
+	return strings.TrimRight(value, "/"), nil
+}
+
+// parseGoProxy splits a GOPROXY value into its entries, keeping track of
+// whether each was separated from the next by a comma or a pipe.
+func parseGoProxy(value string) []proxyEntry {
+	if strings.TrimSpace(value) == "" {
+		value = defaultGoProxy
+	}
+
+	var entries []proxyEntry
+	for value != "" {
+		end := strings.IndexAny(value, ",|")
+		entry, fallbackOnError := value, false
+		if end >= 0 {
+			entry, fallbackOnError = value[:end], value[end] == '|'
+			value = value[end+1:]
+		} else {
+			value = ""
+		}
+
+		if entry = strings.TrimRight(strings.TrimSpace(entry), "/"); entry != "" {
+			entries = append(entries, proxyEntry{base: entry, fallbackOnError: fallbackOnError})
+		}
+	}
+	return entries
+}
+
+// proxyBases returns the configured proxy URLs (and "direct"/"off" keywords)
+// in the order they are tried.
+func proxyBases() []string {
+	bases := make([]string, 0, len(proxies))
+	for _, proxy := range proxies {
+		bases = append(bases, proxy.base)
+	}
+	return bases
+}
+
+// proxyVersionPath is the proxy path of the ext file (".info", ".mod" or
+// ".zip") of moduleName at version. Both are escaped, so uppercase letters in
+// either are case-encoded as the proxy protocol requires: github.com/Azure/sdk
+// at v1.0.0 becomes "github.com/!azure/sdk/@v/v1.0.0.info".
+func proxyVersionPath(moduleName, version, ext string) (string, error) {
+	escapedPath, err := module.EscapePath(moduleName)
+	if err != nil {
+		return "", invalidInput("invalid module path %s: %v", moduleName, err)
+	}
+
+	escapedVersion, err := module.EscapeVersion(version)
+	if err != nil {
+		return "", invalidInput("invalid version %s: %v", version, err)
+	}
+
+	return fmt.Sprintf("%s/@v/%s%s", escapedPath, escapedVersion, ext), nil
+}
+
+// proxyRequest fetches path (e.g. "golang.org/x/mod/@latest") from each
+// configured proxy in turn, following the go command's GOPROXY semantics:
+// comma-separated entries fall through only on 404/410, pipe-separated
+// entries on any error. The component cannot talk to version control
+// systems, so "direct" ends the list, and modules matching GONOPROXY or
+// GOPRIVATE are refused without contacting any proxy.
+//
+// With raceProxies set, the proxies up to the first "direct" or "off" are
+// asked concurrently instead; see proxyRace.
+func proxyRequest(ctx context.Context, path string) ([]byte, error) {
+	if raceProxies {
+		if bases := racedProxies(); len(bases) > 1 {
+			return proxyRace(ctx, path, bases)
+		}
+	}
+	return proxyFetch(path, func(url string) ([]byte, error) {
+		return httpRequest(ctx, url)
+	})
+}
+
+// racedProxies returns the proxy URLs proxyRace asks: those listed before
+// the first "direct" or "off".
+func racedProxies() []string {
+	var bases []string
+	for _, proxy := range proxies {
+		if proxy.base == "direct" || proxy.base == "off" {
+			break
+		}
+		bases = append(bases, proxy.base)
+	}
+	return bases
+}
+
+// proxyRace fetches path from every proxy in bases concurrently and returns
+// the first successful response, cancelling the requests still in flight.
+// A 404 or 410 from one proxy only takes it out of the race; if every proxy
+// fails, the first failure other than a 404 or 410 is returned, or else the
+// first proxy's answer.
+func proxyRace(parent context.Context, path string, bases []string) ([]byte, error) {
+	if err := checkProxyFetch(path); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	type answer struct {
+		index int
+		data  []byte
+		err   error
+	}
+	answers := make(chan answer, len(bases))
+	for i, base := range bases {
+		go func(i int, url string) {
+			data, err := httpRequest(ctx, url)
+			answers <- answer{index: i, data: data, err: err}
+		}(i, fmt.Sprintf("%s/%s", base, path))
+	}
+
+	errs := make([]error, len(bases))
+	for range bases {
+		answer := <-answers
+		if answer.err == nil {
+			logger.Debug("proxy race won", "proxy", bases[answer.index], "path", path)
+			return answer.data, nil
+		}
+		errs[answer.index] = answer.err
+	}
+
+	for _, err := range errs {
+		if !isNotFound(err) {
+			return nil, err
+		}
+	}
+	return nil, errs[0]
+}
+
+// checkProxyFetch returns why path must not be fetched from any proxy: an
+// invalid GOMODULE_PROXY, or a module excluded by GONOPROXY or GOPRIVATE.
+func checkProxyFetch(path string) error {
+	if proxyConfigError != nil {
+		return proxyConfigError
+	}
+	return checkProxyAllowed(proxyPathModule(path))
+}
+
+// proxyRequestLimit is proxyRequest for downloads bounded to limit bytes.
+func proxyRequestLimit(ctx context.Context, path string, limit int64) ([]byte, error) {
+	return proxyFetch(path, func(url string) ([]byte, error) {
+		return httpRequestLimit(ctx, url, limit)
+	})
+}
+
+// proxyFetch implements proxyRequest, fetching each proxy URL with fetch.
+func proxyFetch(path string, fetch func(url string) ([]byte, error)) ([]byte, error) {
+	if err := checkProxyFetch(path); err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+	for _, proxy := range proxies {
+		switch proxy.base {
+		case "off":
+			if lastErr != nil {
+				return nil, lastErr
+			}
+			return nil, invalidInput("module lookup disabled by GOPROXY=off")
+		case "direct":
+			if lastErr != nil {
+				return nil, lastErr
+			}
+			return nil, invalidInput("GOPROXY=direct is not supported: the component can only fetch from module proxies")
+		}
+
+		data, err := fetch(fmt.Sprintf("%s/%s", proxy.base, path))
+		if err == nil {
+			return data, nil
+		}
+
+		lastErr = err
+		if errors.Is(err, errCancelled) || !proxy.fallbackOnError && !isNotFound(err) {
+			return nil, err
+		}
+	}
+
+	if lastErr == nil {
+		lastErr = invalidInput("no module proxy configured")
+	}
+	return nil, lastErr
+}
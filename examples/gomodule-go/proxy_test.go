@@ -0,0 +1,53 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import "testing"
+
+func TestParseProxyURL(t *testing.T) {
+	tests := []struct {
+		value    string
+		insecure bool
+		want     string
+		wantErr  bool
+	}{
+		{value: "https://athens.example.com/", want: "https://athens.example.com"},
+		{value: "https://example.com/go/proxy//", want: "https://example.com/go/proxy"},
+		{value: "http://localhost:3000", wantErr: true},
+		{value: "http://localhost:3000", insecure: true, want: "http://localhost:3000"},
+		{value: "athens.example.com", wantErr: true},
+		{value: "ftp://example.com", wantErr: true},
+		{value: "https://example.com/?x=1", wantErr: true},
+	}
+	saved := insecure
+	t.Cleanup(func() { insecure = saved })
+	for _, tt := range tests {
+		insecure = tt.insecure
+		got, err := parseProxyURL(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseProxyURL(%q) with insecure %v = %q, %v; want %q, error %v", tt.value, tt.insecure, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestProxyVersionPath(t *testing.T) {
+	tests := []struct {
+		module, version, ext string
+		want                 string
+	}{
+		{"golang.org/x/mod", "v0.14.0", ".info", "golang.org/x/mod/@v/v0.14.0.info"},
+		{"github.com/Azure/sdk", "v1.0.0", ".mod", "github.com/!azure/sdk/@v/v1.0.0.mod"},
+		{"example.com/m", "v1.0.0-RC1", ".zip", "example.com/m/@v/v1.0.0-!r!c1.zip"},
+	}
+	for _, tt := range tests {
+		got, err := proxyVersionPath(tt.module, tt.version, tt.ext)
+		if err != nil || got != tt.want {
+			t.Errorf("proxyVersionPath(%q, %q, %q) = %q, %v; want %q", tt.module, tt.version, tt.ext, got, err, tt.want)
+		}
+	}
+
+	if _, err := proxyVersionPath("example.com/a b", "v1.0.0", ".info"); err == nil {
+		t.Error("proxyVersionPath accepted a module path with a space")
+	}
+}
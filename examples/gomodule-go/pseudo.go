@@ -0,0 +1,80 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.bytecodealliance.org/cm"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+type DecodePseudoVersionResult = cm.Result[string, string, string]
+
+// pseudoVersion describes the parts of a pseudo-version. Form is "no_base"
+// for vX.0.0-yyyymmddhhmmss-abcdef123456 (no earlier tag),
+// "prerelease_base" for vX.Y.Z-pre.0.yyyymmddhhmmss-abcdef123456 and
+// "release_base" for vX.Y.(Z+1)-0.yyyymmddhhmmss-abcdef123456. Only
+// IsPseudo is set for versions that are not pseudo-versions.
+type pseudoVersion struct {
+	Version      string `json:"version,omitempty"`
+	IsPseudo     bool   `json:"is_pseudo"`
+	Form         string `json:"form,omitempty"`
+	Base         string `json:"base,omitempty"`
+	Time         string `json:"time,omitempty"`
+	Revision     string `json:"revision,omitempty"`
+	Incompatible bool   `json:"incompatible,omitempty"`
+}
+
+// decodePseudoVersion splits version into the base tag, commit time and
+// revision the go command encoded in it, using x/mod's pseudo-version rules.
+func decodePseudoVersion(_ context.Context, version string) DecodePseudoVersionResult {
+	version = canonicalVersion(version)
+	if version == "" {
+		return fail[DecodePseudoVersionResult](invalidInput("Version must not be empty"))
+	}
+
+	result := pseudoVersion{}
+	if module.IsPseudoVersion(version) {
+		base, err := module.PseudoVersionBase(version)
+		if err != nil {
+			return fail[DecodePseudoVersionResult](invalidInput("Invalid pseudo-version %q: %v", version, err))
+		}
+		commitTime, err := module.PseudoVersionTime(version)
+		if err != nil {
+			return fail[DecodePseudoVersionResult](invalidInput("Invalid pseudo-version %q: %v", version, err))
+		}
+		rev, err := module.PseudoVersionRev(version)
+		if err != nil {
+			return fail[DecodePseudoVersionResult](invalidInput("Invalid pseudo-version %q: %v", version, err))
+		}
+
+		result = pseudoVersion{
+			Version:      version,
+			IsPseudo:     true,
+			Form:         "release_base",
+			Base:         base,
+			Time:         commitTime.Format(time.RFC3339),
+			Revision:     rev,
+			Incompatible: semver.Build(version) == "+incompatible",
+		}
+		switch {
+		case base == "":
+			result.Form = "no_base"
+		case semver.Prerelease(base) != "":
+			result.Form = "prerelease_base"
+		}
+	}
+
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		return fail[DecodePseudoVersionResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[DecodePseudoVersionResult](string(jsonData))
+}
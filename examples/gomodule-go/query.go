@@ -0,0 +1,158 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.bytecodealliance.org/cm"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+type ResolveQueryResult = cm.Result[string, string, string]
+
+// versionPrefixQuery matches the "v1" and "v1.2" queries, which select the
+// newest version with that major or major.minor.
+var versionPrefixQuery = regexp.MustCompile(`^v(0|[1-9]\d*)(\.(0|[1-9]\d*))?$`)
+
+// queryResolution is the result of resolveQuery. Kind says how the query
+// was read: "latest", "prefix", "comparison", "version" or "revision".
+// When nothing matches, Found is false and Message says why.
+type queryResolution struct {
+	Module  string `json:"module"`
+	Query   string `json:"query"`
+	Kind    string `json:"kind"`
+	Found   bool   `json:"found"`
+	Version string `json:"version,omitempty"`
+	Time    string `json:"time,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// resolveQuery resolves a version query to a single version of moduleName
+// the way "go get module@query" does:
+//
+//   - "latest" (or empty) and "upgrade" ask the proxy for @latest; without a
+//     current version to upgrade from, upgrade means latest.
+//   - "v1" and "v1.2" select the newest version with that prefix.
+//   - "<v1.2.3", "<=v1.2.3", ">v1.2.3" and ">=v1.2.3" select the matching
+//     version closest to v1.2.3.
+//   - A full semantic version is looked up as is.
+//   - Anything else is a branch, tag or commit hash resolved by the proxy,
+//     usually to a pseudo-version. Only queries starting with "v" are read
+//     as versions or prefixes, so the short hash 1234567 is not v1234567.
+//
+// Prefix and comparison queries prefer releases to prereleases, as go get
+// does, and only consider the tagged versions in the proxy's list.
+func resolveQuery(ctx context.Context, moduleName, query string) ResolveQueryResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[ResolveQueryResult](invalidInput("Module name must not be empty"))
+	}
+
+	query = strings.TrimPrefix(strings.TrimSpace(query), "@")
+	if query == "" {
+		query = "latest"
+	}
+	result := queryResolution{Module: moduleName, Query: query}
+
+	var info *versionInfo
+	var err error
+	switch op, target := splitOperator(query); {
+	case query == "latest" || query == "upgrade":
+		result.Kind = "latest"
+		info, err = fetchLatestInfo(ctx, moduleName)
+	case query == "patch":
+		return fail[ResolveQueryResult](invalidInput(`Invalid query "patch": it needs a current version to stay within; use get-latest-patch instead`))
+	case op != "":
+		target = canonicalVersion(target)
+		if op == "=" || op == "~" || op == "^" || !semver.IsValid(target) {
+			return fail[ResolveQueryResult](invalidInput("Invalid query %q: expected a comparison such as >=v1.2.0", query))
+		}
+		result.Kind = "comparison"
+		bound := versionBound{op: op, version: target}
+		info, err = resolveFromList(ctx, moduleName, bound.matches, op == ">" || op == ">=", &result)
+	case versionPrefixQuery.MatchString(query):
+		result.Kind = "prefix"
+		info, err = resolveFromList(ctx, moduleName, func(v string) bool {
+			return semver.Major(v) == query || semver.MajorMinor(v) == query
+		}, false, &result)
+	case semver.IsValid(query):
+		result.Kind = "version"
+		info, err = fetchVersionInfo(ctx, moduleName, query)
+	default:
+		if !revisionPattern.MatchString(query) || strings.Contains(query, "..") || strings.HasSuffix(query, ".lock") {
+			return fail[ResolveQueryResult](invalidInput("Invalid query %q: expected latest, a version prefix, a comparison, a version or a revision", query))
+		}
+		result.Kind = "revision"
+		info, err = fetchVersionInfo(ctx, moduleName, query)
+	}
+
+	switch {
+	case err != nil && isNotFound(err):
+		result.Message = err.Error()
+	case err != nil:
+		return fail[ResolveQueryResult](err)
+	case info != nil:
+		result.Found = true
+		result.Version = info.Version
+		if !info.Time.IsZero() {
+			result.Time = info.Time.Format(time.RFC3339)
+		}
+	}
+
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		return fail[ResolveQueryResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[ResolveQueryResult](string(jsonData))
+}
+
+// resolveFromList picks the version of moduleName closest to a query from
+// the tagged versions satisfying matches: the lowest when preferLower is
+// set, otherwise the highest. It returns nil, with result.Message set, when
+// none match.
+func resolveFromList(ctx context.Context, moduleName string, matches func(string) bool, preferLower bool, result *queryResolution) (*versionInfo, error) {
+	data, err := proxyRequest(ctx, escapedListPath(moduleName))
+	if err != nil {
+		if isNotFound(err) {
+			return nil, describe(err, "module not found: %s", moduleName)
+		}
+		return nil, fmt.Errorf("transport failure: fetching %s: %w", moduleName, err)
+	}
+
+	var releases, prereleases []string
+	for _, v := range parseVersionList(data) {
+		if !semver.IsValid(v) || module.IsPseudoVersion(v) || !matches(v) {
+			continue
+		}
+		if semver.Prerelease(v) == "" {
+			releases = append(releases, v)
+		} else {
+			prereleases = append(prereleases, v)
+		}
+	}
+
+	candidates := releases
+	if len(candidates) == 0 {
+		candidates = prereleases
+	}
+	if len(candidates) == 0 {
+		result.Message = fmt.Sprintf("no version of %s matches %q", moduleName, result.Query)
+		return nil, nil
+	}
+
+	sortVersions(candidates)
+	closest := candidates[len(candidates)-1]
+	if preferLower {
+		closest = candidates[0]
+	}
+	return fetchVersionInfo(ctx, moduleName, closest)
+}
@@ -0,0 +1,49 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestResolveQuery(t *testing.T) {
+	fakeRoutes(t, map[string]string{
+		"proxy.test/example.com/m/@v/list":         "v1.2.0\nv1.2.1\nv1.3.0\nv1.4.0-rc.1\nv1234567.0.0\n",
+		"proxy.test/example.com/m/@v/v1.2.1.info":  `{"Version":"v1.2.1"}`,
+		"proxy.test/example.com/m/@v/v1.3.0.info":  `{"Version":"v1.3.0"}`,
+		"proxy.test/example.com/m/@v/v1.2.0.info":  `{"Version":"v1.2.0"}`,
+		"proxy.test/example.com/m/@v/1234567.info": `{"Version":"v0.0.0-20240102150405-1234567abcde"}`,
+		"proxy.test/example.com/m/@v/main.info":    `{"Version":"v0.0.0-20240103150405-abcdef123456"}`,
+	})
+
+	tests := []struct {
+		query   string
+		kind    string
+		version string
+	}{
+		{"v1.2", "prefix", "v1.2.1"},
+		{"v1", "prefix", "v1.3.0"},
+		{"v1.2.0", "version", "v1.2.0"},
+		{">=v1.2.1", "comparison", "v1.2.1"},
+		{"<1.3.0", "comparison", "v1.2.1"},
+		{"1234567", "revision", "v0.0.0-20240102150405-1234567abcde"},
+		{"main", "revision", "v0.0.0-20240103150405-abcdef123456"},
+	}
+	for _, tt := range tests {
+		result := resolveQuery(context.Background(), "example.com/m", tt.query)
+		if result.IsErr() {
+			t.Errorf("resolveQuery(%q) failed: %s", tt.query, *result.Err())
+			continue
+		}
+		var got queryResolution
+		if err := json.Unmarshal([]byte(*result.OK()), &got); err != nil {
+			t.Fatalf("failed to parse result of %q: %v", tt.query, err)
+		}
+		if got.Kind != tt.kind || got.Version != tt.version {
+			t.Errorf("resolveQuery(%q) = %s %s, want %s %s", tt.query, got.Kind, got.Version, tt.kind, tt.version)
+		}
+	}
+}
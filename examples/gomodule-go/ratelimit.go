@@ -0,0 +1,85 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// defaultRateLimit is how many requests a second the component sends unless
+// overridden by the GOMODULE_RATE_LIMIT environment variable.
+const defaultRateLimit = 10
+
+// rateLimiter is a token bucket holding up to one second's worth of
+// requests. A rate of zero disables it.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+// errRateLimit is wrapped by the error of requests the limiter refuses.
+var errRateLimit = errors.New("rate limit")
+
+// limiter paces every outbound request, across all tools and goroutines.
+var limiter = newRateLimiter(defaultRateLimit)
+
+func init() {
+	if value := os.Getenv("GOMODULE_RATE_LIMIT"); value != "" {
+		if rate, err := strconv.ParseFloat(value, 64); err == nil && rate >= 0 {
+			limiter = newRateLimiter(rate)
+		}
+	}
+}
+
+func newRateLimiter(rate float64) *rateLimiter {
+	return &rateLimiter{rate: rate, tokens: max(rate, 1), last: time.Now()}
+}
+
+// wait blocks until a request may be sent. It fails instead of waiting past
+// the deadline of ctx, so a throttled request still honours requestTimeout,
+// and returns ctx.Err() if ctx is cancelled while it waits.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l.rate == 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = min(l.tokens+now.Sub(l.last).Seconds()*l.rate, max(l.rate, 1))
+	l.last = now
+
+	// Take the token now, even if it is not there yet, so that concurrent
+	// callers queue up behind each other instead of waking together.
+	l.tokens--
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	if deadline, ok := ctx.Deadline(); ok && now.Add(delay).After(deadline) {
+		l.tokens++
+		l.mu.Unlock()
+		return fmt.Errorf("%w of %g requests per second would delay the request past its %v timeout", errRateLimit, l.rate, requestTimeout)
+	}
+	l.mu.Unlock()
+
+	if delay > 0 {
+		logger.Debug("rate limited", "delay", delay)
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"go.bytecodealliance.org/cm"
+)
+
+type GetModuleReadmeResult = cm.Result[string, string, string]
+
+// maxReadmeSize bounds how much of a README getModuleReadme returns.
+const maxReadmeSize = 64 << 10
+
+// readmeNames are the README files looked for at the module root, in order
+// of preference. They are matched case-insensitively.
+var readmeNames = []string{"readme.md", "readme", "readme.rst"}
+
+// moduleReadme is the result of getModuleReadme. Found is false, and the
+// other fields empty, when the module root has no README.
+type moduleReadme struct {
+	Module    string `json:"module"`
+	Version   string `json:"version"`
+	Found     bool   `json:"found"`
+	File      string `json:"file,omitempty"`
+	Size      uint64 `json:"size,omitempty"`
+	Truncated bool   `json:"truncated"`
+	Content   string `json:"content,omitempty"`
+}
+
+// getModuleReadme returns the README at the root of the module zip of
+// moduleName at version (latest when empty). Content beyond maxReadmeSize is
+// cut off and ends with a truncation marker.
+func getModuleReadme(ctx context.Context, moduleName, version string) GetModuleReadmeResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[GetModuleReadmeResult](invalidInput("Module name must not be empty"))
+	}
+
+	if version == "" {
+		latest, err := resolveLatestVersion(ctx, moduleName)
+		if err != nil {
+			return fail[GetModuleReadmeResult](err)
+		}
+		version = latest
+	}
+
+	files, err := openModuleZip(ctx, moduleName, version)
+	if err != nil {
+		return fail[GetModuleReadmeResult](err)
+	}
+
+	result := moduleReadme{Module: moduleName, Version: version}
+	if file := findReadme(files); file != nil {
+		content, err := readReadme(file.File)
+		if err != nil {
+			return fail[GetModuleReadmeResult](fmt.Errorf("Failed to read %s: %w", file.Name, err))
+		}
+		result.Found = true
+		result.File = file.Path
+		result.Size = file.UncompressedSize64
+		result.Truncated = file.UncompressedSize64 > maxReadmeSize
+		result.Content = content
+	}
+
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		return fail[GetModuleReadmeResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[GetModuleReadmeResult](string(jsonData))
+}
+
+// findReadme returns the preferred README at the module root, or nil when
+// there is none.
+func findReadme(files []moduleZipFile) *moduleZipFile {
+	var best *moduleZipFile
+	bestRank := len(readmeNames)
+	for i, file := range files {
+		if strings.Contains(file.Path, "/") {
+			continue
+		}
+		for rank, readme := range readmeNames {
+			if rank < bestRank && strings.EqualFold(file.Path, readme) {
+				best, bestRank = &files[i], rank
+			}
+		}
+	}
+	return best
+}
+
+// readReadme reads at most maxReadmeSize bytes of file, appending a marker
+// when the rest was cut off.
+func readReadme(file *zip.File) (string, error) {
+	r, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+
+	content, err := io.ReadAll(io.LimitReader(r, maxReadmeSize))
+	if err != nil {
+		return "", err
+	}
+	if file.UncompressedSize64 > maxReadmeSize {
+		return fmt.Sprintf("%s\n\n[truncated: %s of %s shown]", content, formatBytes(maxReadmeSize), formatBytes(int64(file.UncompressedSize64))), nil
+	}
+	return string(content), nil
+}
@@ -0,0 +1,47 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestGetModuleReadme(t *testing.T) {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range map[string]string{
+		"example.com/m@v1.0.0/docs/README.md": "nested",
+		"example.com/m@v1.0.0/README":         "plain",
+		"example.com/m@v1.0.0/ReadMe.md":      "markdown",
+		"example.com/m@v1.0.0/go.mod":         "module example.com/m\n",
+	} {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Write([]byte(content))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	fakeRoutes(t, map[string]string{
+		"proxy.test/example.com/m/@v/v1.0.0.zip": buf.String(),
+	})
+
+	result := getModuleReadme(context.Background(), "example.com/m", "v1.0.0")
+	if result.IsErr() {
+		t.Fatalf("getModuleReadme failed: %s", *result.Err())
+	}
+	var got moduleReadme
+	if err := json.Unmarshal([]byte(*result.OK()), &got); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+	if !got.Found || got.File != "ReadMe.md" || got.Content != "markdown" {
+		t.Errorf("getModuleReadme = %+v, want ReadMe.md with its content", got)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.bytecodealliance.org/cm"
+	"golang.org/x/mod/module"
+)
+
+type GetReleaseNotesResult = cm.Result[string, string, string]
+
+// githubAPIURL is the GitHub REST API.
+const githubAPIURL = "https://api.github.com"
+
+// githubToken, from the GITHUB_TOKEN environment variable, authenticates
+// GitHub API requests, which raises the rate limit from 60 to 5000 requests
+// an hour.
+var githubToken = os.Getenv("GITHUB_TOKEN")
+
+// releaseNotes is the result of getReleaseNotes. Available is false, with a
+// message, for modules not hosted on GitHub and versions without a release.
+type releaseNotes struct {
+	Module     string `json:"module"`
+	Version    string `json:"version"`
+	Available  bool   `json:"available"`
+	Message    string `json:"message,omitempty"`
+	Tag        string `json:"tag,omitempty"`
+	Name       string `json:"name,omitempty"`
+	URL        string `json:"url,omitempty"`
+	Published  string `json:"published,omitempty"`
+	Prerelease bool   `json:"prerelease,omitempty"`
+	Body       string `json:"body,omitempty"`
+}
+
+// getReleaseNotes returns the markdown body of the GitHub release of
+// moduleName at version (latest when empty).
+func getReleaseNotes(ctx context.Context, moduleName, version string) GetReleaseNotesResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[GetReleaseNotesResult](invalidInput("Module name must not be empty"))
+	}
+
+	if version == "" {
+		latest, err := resolveLatestVersion(ctx, moduleName)
+		if err != nil {
+			return fail[GetReleaseNotesResult](err)
+		}
+		version = latest
+	}
+
+	if err := checkPublic(moduleName); err != nil {
+		return fail[GetReleaseNotesResult](err)
+	}
+
+	notes := releaseNotes{Module: moduleName, Version: version, Message: "no release notes available"}
+	repo, err := resolveRepository(ctx, moduleName)
+	if err != nil || repo.Host != "github.com" {
+		return marshalReleaseNotes(notes)
+	}
+
+	tag := releaseTag(moduleName, repo, version)
+	data, err := httpRequest(ctx, fmt.Sprintf("%s/repos/%s/releases/tags/%s", githubAPIURL, repo.Path, tag))
+	if err != nil {
+		if isNotFound(err) {
+			return marshalReleaseNotes(notes)
+		}
+		return fail[GetReleaseNotesResult](fmt.Errorf("Failed to fetch the GitHub release %s of %s: %w", tag, repo.URL, err))
+	}
+
+	var release struct {
+		TagName     string `json:"tag_name"`
+		Name        string `json:"name"`
+		HTMLURL     string `json:"html_url"`
+		PublishedAt string `json:"published_at"`
+		Prerelease  bool   `json:"prerelease"`
+		Body        string `json:"body"`
+	}
+	if err := json.Unmarshal(data, &release); err != nil {
+		return fail[GetReleaseNotesResult](fmt.Errorf("Failed to parse the GitHub release %s of %s: %w", tag, repo.URL, err))
+	}
+
+	notes.Available = true
+	notes.Message = ""
+	notes.Tag = release.TagName
+	notes.Name = release.Name
+	notes.URL = release.HTMLURL
+	notes.Published = release.PublishedAt
+	notes.Prerelease = release.Prerelease
+	notes.Body = release.Body
+	return marshalReleaseNotes(notes)
+}
+
+// releaseTag is the git tag of moduleName at version in repo. Modules in a
+// subdirectory of their repository are tagged "<subdir>/<version>"; a major
+// version suffix such as /v2 is not part of the directory.
+func releaseTag(moduleName string, repo *repository, version string) string {
+	prefix, _, _ := module.SplitPathVersion(moduleName)
+	subdir := strings.Trim(strings.TrimPrefix(prefix, repo.Host+"/"+repo.Path), "/")
+	if subdir == "" || !strings.HasPrefix(prefix, repo.Host+"/"+repo.Path+"/") {
+		return version
+	}
+	return subdir + "/" + version
+}
+
+func marshalReleaseNotes(notes releaseNotes) GetReleaseNotesResult {
+	jsonData, err := json.Marshal(notes)
+	if err != nil {
+		return fail[GetReleaseNotesResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[GetReleaseNotesResult](string(jsonData))
+}
@@ -0,0 +1,112 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// goImportMeta matches the go-import meta tag of a vanity import page.
+var goImportMeta = regexp.MustCompile(`<meta\s+name="go-import"\s+content="([^"]*)"`)
+
+// repository is the source repository a module is developed in.
+type repository struct {
+	// Host and Path split the repository URL, e.g. "github.com" and
+	// "spf13/cobra".
+	Host string `json:"host"`
+	Path string `json:"path"`
+	URL  string `json:"url"`
+}
+
+// resolveRepository finds the source repository of moduleName from the VCS
+// origin the proxy recorded for its latest version, falling back to the
+// module's go-import meta tag and finally to the module path itself for
+// well-known code hosts.
+func resolveRepository(ctx context.Context, moduleName string) (*repository, error) {
+	_, origin, err := fetchOrigin(ctx, moduleName)
+	if err != nil {
+		return nil, err
+	}
+	if origin != nil && origin.URL != "" {
+		return parseRepositoryURL(origin.URL)
+	}
+
+	if repoURL, err := fetchGoImport(ctx, moduleName); err == nil {
+		return parseRepositoryURL(repoURL)
+	}
+
+	parts := strings.Split(moduleName, "/")
+	switch parts[0] {
+	case "github.com", "gitlab.com", "bitbucket.org":
+		if len(parts) >= 3 {
+			return parseRepositoryURL("https://" + strings.Join(parts[:3], "/"))
+		}
+	}
+
+	return nil, fmt.Errorf("could not determine the source repository of %s", moduleName)
+}
+
+// parseRepositoryURL splits an https repository URL into host and path.
+func parseRepositoryURL(repoURL string) (*repository, error) {
+	parsed, err := url.Parse(repoURL)
+	if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		return nil, fmt.Errorf("repository URL %q is not an https URL", repoURL)
+	}
+
+	path := strings.TrimSuffix(strings.Trim(parsed.Path, "/"), ".git")
+	return &repository{
+		Host: strings.ToLower(parsed.Host),
+		Path: path,
+		URL:  "https://" + strings.ToLower(parsed.Host) + "/" + path,
+	}, nil
+}
+
+// goImport is a go-import meta tag: the import path prefix it covers, the
+// VCS ("git", "hg", ... or "mod" for a module proxy) and the repository URL.
+type goImport struct {
+	Prefix  string
+	VCS     string
+	RepoURL string
+}
+
+// fetchGoImport reads the repository URL from the go-import meta tag served
+// at https://<moduleName>?go-get=1, as the go command does for vanity
+// import paths.
+func fetchGoImport(ctx context.Context, moduleName string) (string, error) {
+	meta, err := fetchGoImportMeta(ctx, moduleName)
+	if err != nil {
+		return "", err
+	}
+	return meta.RepoURL, nil
+}
+
+// fetchGoImportMeta returns the go-import meta tag of moduleName.
+func fetchGoImportMeta(ctx context.Context, moduleName string) (*goImport, error) {
+	if err := checkPublic(moduleName); err != nil {
+		return nil, err
+	}
+
+	data, err := httpRequest(ctx, "https://"+moduleName+"?go-get=1")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch go-import metadata for %s: %w", moduleName, err)
+	}
+
+	for _, match := range goImportMeta.FindAllStringSubmatch(string(data), -1) {
+		fields := strings.Fields(html.UnescapeString(match[1]))
+		if len(fields) != 3 {
+			continue
+		}
+		meta := &goImport{Prefix: fields[0], VCS: fields[1], RepoURL: fields[2]}
+		if moduleName == meta.Prefix || strings.HasPrefix(moduleName, meta.Prefix+"/") {
+			return meta, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no go-import meta tag for %s", moduleName)
+}
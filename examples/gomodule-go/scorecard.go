@@ -0,0 +1,184 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"go.bytecodealliance.org/cm"
+)
+
+type GetScorecardResult = cm.Result[string, string, string]
+type GetRepositoryScorecardResult = cm.Result[string, string, string]
+
+// scorecardURL is the OpenSSF Scorecard API.
+const scorecardURL = "https://api.securityscorecards.dev"
+
+// scorecardCheck is the score of a single Scorecard check, from 0 to 10, or
+// -1 when the check could not be run.
+type scorecardCheck struct {
+	Name   string  `json:"name"`
+	Score  float64 `json:"score"`
+	Reason string  `json:"reason"`
+}
+
+// scorecardReport is the Scorecard of the source repository of one module.
+type scorecardReport struct {
+	Version    string           `json:"version"`
+	Repository string           `json:"repository,omitempty"`
+	Available  bool             `json:"available"`
+	Message    string           `json:"message,omitempty"`
+	Date       string           `json:"date,omitempty"`
+	Score      *float64         `json:"score,omitempty"`
+	Checks     []scorecardCheck `json:"checks,omitempty"`
+}
+
+// getScorecard reports the OpenSSF Scorecard deps.dev holds for the source
+// repository of the latest version of every module. Each module costs up to
+// four requests (@latest, the deps.dev version, the repository lookup when
+// deps.dev links none, and the deps.dev project), and project responses
+// carry every check's documentation, so this is slower than proxy-only tools.
+func getScorecard(ctx context.Context, moduleNames string) GetScorecardResult {
+	modules, invalid, err := normalizeModules(moduleNames)
+	if err != nil {
+		return fail[GetScorecardResult](err)
+	}
+	reports := make([]*scorecardReport, len(modules))
+	failures := make([]error, len(modules))
+
+	forEachModule(ctx, modules, func(i int, moduleName string) {
+		reports[i], failures[i] = moduleScorecard(ctx, moduleName)
+	})
+
+	batch := newBatchResult(invalid)
+	for i, moduleName := range modules {
+		if failures[i] != nil {
+			batch.addError(moduleName, failures[i])
+			continue
+		}
+		batch.add(moduleName, reports[i])
+	}
+	return batchOutput[GetScorecardResult](batch, "get scorecards")
+}
+
+// moduleScorecard looks up the Scorecard of the latest version of
+// moduleName. Modules deps.dev has not indexed, and repositories without a
+// scorecard, are reported with available unset and a message.
+func moduleScorecard(ctx context.Context, moduleName string) (*scorecardReport, error) {
+	version, err := resolveLatestVersion(ctx, moduleName)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := fetchDepsDevVersion(ctx, moduleName, version)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &scorecardReport{Version: version}
+	if info == nil {
+		report.Message = "not indexed by deps.dev"
+		return report, nil
+	}
+
+	project := info.sourceRepository()
+	if project == "" {
+		repo, err := resolveRepository(ctx, moduleName)
+		if err != nil {
+			return nil, err
+		}
+		project = repo.Host + "/" + repo.Path
+	}
+	report.Repository = "https://" + project
+
+	data, err := httpRequest(ctx, fmt.Sprintf("%s/projects/%s", depsDevURL, url.PathEscape(project)))
+	if err != nil {
+		if isNotFound(err) {
+			report.Message = "repository not indexed by deps.dev"
+			return report, nil
+		}
+		return nil, fmt.Errorf("failed to query deps.dev for %s: %w", project, err)
+	}
+
+	var response struct {
+		Scorecard *struct {
+			Date         string           `json:"date"`
+			OverallScore float64          `json:"overallScore"`
+			Checks       []scorecardCheck `json:"checks"`
+		} `json:"scorecard"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse deps.dev response for %s: %w", project, err)
+	}
+
+	if response.Scorecard == nil {
+		report.Message = "no scorecard available"
+		return report, nil
+	}
+
+	report.Available = true
+	report.Date = response.Scorecard.Date
+	report.Score = &response.Scorecard.OverallScore
+	report.Checks = response.Scorecard.Checks
+	return report, nil
+}
+
+// repositoryScorecard is the result of getRepositoryScorecard.
+type repositoryScorecard struct {
+	Module     string           `json:"module"`
+	Repository string           `json:"repository"`
+	Available  bool             `json:"available"`
+	Message    string           `json:"message,omitempty"`
+	Date       string           `json:"date,omitempty"`
+	Score      *float64         `json:"score,omitempty"`
+	Checks     []scorecardCheck `json:"checks,omitempty"`
+}
+
+// getRepositoryScorecard reports the OpenSSF Scorecard the Scorecard API
+// publishes for the repository moduleName is developed in. Unlike
+// getScorecard it does not go through deps.dev, so it also covers modules
+// deps.dev has not indexed, but it looks up only one module.
+func getRepositoryScorecard(ctx context.Context, moduleName string) GetRepositoryScorecardResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[GetRepositoryScorecardResult](invalidInput("Module name must not be empty"))
+	}
+
+	repo, err := resolveRepository(ctx, moduleName)
+	if err != nil {
+		return fail[GetRepositoryScorecardResult](err)
+	}
+
+	report := repositoryScorecard{Module: moduleName, Repository: repo.URL}
+	data, err := httpRequest(ctx, fmt.Sprintf("%s/projects/%s/%s", scorecardURL, repo.Host, repo.Path))
+	switch {
+	case err == nil:
+		var scorecard struct {
+			Date   string           `json:"date"`
+			Score  float64          `json:"score"`
+			Checks []scorecardCheck `json:"checks"`
+		}
+		if err := json.Unmarshal(data, &scorecard); err != nil {
+			return fail[GetRepositoryScorecardResult](fmt.Errorf("Failed to parse scorecard for %s: %w", repo.URL, err))
+		}
+		report.Available = true
+		report.Date = scorecard.Date
+		report.Score = &scorecard.Score
+		report.Checks = scorecard.Checks
+	case isNotFound(err):
+		report.Message = "no scorecard available"
+	default:
+		return fail[GetRepositoryScorecardResult](fmt.Errorf("Failed to fetch scorecard for %s: %w", repo.URL, err))
+	}
+
+	jsonData, err := json.Marshal(report)
+	if err != nil {
+		return fail[GetRepositoryScorecardResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[GetRepositoryScorecardResult](string(jsonData))
+}
@@ -0,0 +1,47 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestGetRepositoryScorecard(t *testing.T) {
+	fakeRoutes(t, map[string]string{
+		"proxy.test/github.com/example/foo/@latest":                  `{"Version":"v1.0.0"}`,
+		"proxy.test/github.com/example/foo/@v/v1.0.0.info":           `{"Version":"v1.0.0","Origin":{"VCS":"git","URL":"https://github.com/example/foo","Hash":"abcdef"}}`,
+		"proxy.test/github.com/example/bar/@latest":                  `{"Version":"v1.0.0"}`,
+		"proxy.test/github.com/example/bar/@v/v1.0.0.info":           `{"Version":"v1.0.0"}`,
+		"api.securityscorecards.dev/projects/github.com/example/foo": `{"date":"2024-01-01","score":7.5,"checks":[{"name":"Maintained","score":10,"reason":"active"}]}`,
+	})
+
+	tests := []struct {
+		module    string
+		available bool
+		score     float64
+		message   string
+	}{
+		{"github.com/example/foo", true, 7.5, ""},
+		{"github.com/example/bar", false, 0, "no scorecard available"},
+	}
+	for _, tt := range tests {
+		result := getRepositoryScorecard(context.Background(), tt.module)
+		if result.IsErr() {
+			t.Errorf("getRepositoryScorecard(%q) failed: %s", tt.module, *result.Err())
+			continue
+		}
+		var got repositoryScorecard
+		if err := json.Unmarshal([]byte(*result.OK()), &got); err != nil {
+			t.Fatalf("failed to parse result for %q: %v", tt.module, err)
+		}
+		if got.Available != tt.available || got.Message != tt.message {
+			t.Errorf("getRepositoryScorecard(%q) = available %v, message %q; want %v, %q", tt.module, got.Available, got.Message, tt.available, tt.message)
+		}
+		if tt.available && (got.Score == nil || *got.Score != tt.score || len(got.Checks) != 1) {
+			t.Errorf("getRepositoryScorecard(%q) = score %v, checks %v; want %v and one check", tt.module, got.Score, got.Checks, tt.score)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.bytecodealliance.org/cm"
+	"golang.org/x/mod/module"
+)
+
+type GetModuleChecksumsResult = cm.Result[string, string, string]
+type VerifyChecksumResult = cm.Result[string, string, string]
+
+// hashComparison pairs the hash recorded in the checksum database with the
+// one computed from the proxy download.
+type hashComparison struct {
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+	Match    bool   `json:"match"`
+}
+
+// checksumVerification is the result of verifyChecksum.
+type checksumVerification struct {
+	Module   string         `json:"module"`
+	Version  string         `json:"version"`
+	Verified bool           `json:"verified"`
+	Zip      hashComparison `json:"zip"`
+	GoMod    hashComparison `json:"go_mod"`
+}
+
+// sumDBURL is the Go checksum database queried for module hashes.
+const sumDBURL = "https://sum.golang.org"
+
+// sumDBRecord is the parsed body of a checksum database lookup.
+type sumDBRecord struct {
+	Module    string `json:"module"`
+	Version   string `json:"version"`
+	RecordID  int64  `json:"record_id"`
+	ZipHash   string `json:"zip_hash"`
+	GoModHash string `json:"go_mod_hash"`
+}
+
+// lookupChecksums queries the checksum database for moduleName at version.
+func lookupChecksums(ctx context.Context, moduleName, version string) (*sumDBRecord, error) {
+	if key := sumDBDisabledFor(moduleName); key != "" {
+		return nil, &privateModuleError{module: moduleName, variable: key}
+	}
+
+	escapedPath, err := module.EscapePath(moduleName)
+	if err != nil {
+		return nil, invalidInput("invalid module path %s: %v", moduleName, err)
+	}
+
+	escapedVersion, err := module.EscapeVersion(version)
+	if err != nil {
+		return nil, invalidInput("invalid version %s: %v", version, err)
+	}
+
+	data, err := httpRequest(ctx, fmt.Sprintf("%s/lookup/%s@%s", sumDBURL, escapedPath, escapedVersion))
+	if err != nil {
+		if isNotFound(err) {
+			return nil, describe(err, "not in checksum database: %s@%s", moduleName, version)
+		}
+		return nil, fmt.Errorf("transport failure: looking up %s@%s: %w", moduleName, version, err)
+	}
+
+	return parseLookup(moduleName, version, data)
+}
+
+// parseLookup parses a sum.golang.org lookup response: the record ID on the
+// first line, then go.sum lines up to a blank line, then the signed tree head.
+func parseLookup(moduleName, version string, data []byte) (*sumDBRecord, error) {
+	lines := strings.Split(string(data), "\n")
+
+	recordID, err := strconv.ParseInt(strings.TrimSpace(lines[0]), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid record id in lookup response for %s@%s: %q", moduleName, version, lines[0])
+	}
+
+	record := &sumDBRecord{Module: moduleName, Version: version, RecordID: recordID}
+	for _, line := range lines[1:] {
+		if strings.TrimSpace(line) == "" {
+			break
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 3 || fields[0] != moduleName {
+			return nil, fmt.Errorf("unexpected line in lookup response for %s@%s: %q", moduleName, version, line)
+		}
+
+		switch fields[1] {
+		case version:
+			record.ZipHash = fields[2]
+		case version + "/go.mod":
+			record.GoModHash = fields[2]
+		}
+	}
+
+	if record.ZipHash == "" && record.GoModHash == "" {
+		return nil, fmt.Errorf("no hashes in lookup response for %s@%s", moduleName, version)
+	}
+
+	return record, nil
+}
+
+func getModuleChecksums(ctx context.Context, moduleName, version string) GetModuleChecksumsResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[GetModuleChecksumsResult](invalidInput("Module name must not be empty"))
+	}
+
+	if version == "" {
+		latest, err := resolveLatestVersion(ctx, moduleName)
+		if err != nil {
+			return fail[GetModuleChecksumsResult](err)
+		}
+		version = latest
+	}
+
+	record, err := lookupChecksums(ctx, moduleName, version)
+	if err != nil {
+		return fail[GetModuleChecksumsResult](err)
+	}
+
+	jsonData, err := json.Marshal(record)
+	if err != nil {
+		return fail[GetModuleChecksumsResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[GetModuleChecksumsResult](string(jsonData))
+}
+
+// verifyChecksum downloads the go.mod and zip of moduleName at version from
+// the proxy, hashes them and compares the hashes with sum.golang.org.
+func verifyChecksum(ctx context.Context, moduleName, version string) VerifyChecksumResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[VerifyChecksumResult](invalidInput("Module name must not be empty"))
+	}
+
+	if key := sumDBDisabledFor(moduleName); key != "" {
+		return fail[VerifyChecksumResult](invalidInput("Checksum verification disabled for %s by %s", moduleName, key))
+	}
+
+	if version == "" {
+		latest, err := resolveLatestVersion(ctx, moduleName)
+		if err != nil {
+			return fail[VerifyChecksumResult](err)
+		}
+		version = latest
+	}
+
+	record, err := lookupChecksums(ctx, moduleName, version)
+	if err != nil {
+		return fail[VerifyChecksumResult](err)
+	}
+
+	modData, err := fetchGoMod(ctx, moduleName, version)
+	if err != nil {
+		return fail[VerifyChecksumResult](err)
+	}
+	modHash, err := hashGoMod(modData)
+	if err != nil {
+		return fail[VerifyChecksumResult](fmt.Errorf("Failed to hash go.mod for %s@%s: %w", moduleName, version, err))
+	}
+
+	zipData, err := fetchModuleZip(ctx, moduleName, version)
+	if err != nil {
+		return fail[VerifyChecksumResult](err)
+	}
+	zipHash, err := hashZip(zipData)
+	if err != nil {
+		return fail[VerifyChecksumResult](fmt.Errorf("Failed to hash zip for %s@%s: %w", moduleName, version, err))
+	}
+
+	result := checksumVerification{
+		Module:  moduleName,
+		Version: version,
+		Zip:     hashComparison{Expected: record.ZipHash, Actual: zipHash, Match: record.ZipHash == zipHash},
+		GoMod:   hashComparison{Expected: record.GoModHash, Actual: modHash, Match: record.GoModHash == modHash},
+	}
+	result.Verified = result.Zip.Match && result.GoMod.Match
+
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		return fail[VerifyChecksumResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[VerifyChecksumResult](string(jsonData))
+}
@@ -0,0 +1,42 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import "testing"
+
+func TestParseLookup(t *testing.T) {
+	const response = `24719953
+golang.org/x/mod v0.14.0 h1:dGoOF9QVLYng8IHTm7BAyWqCqSheQ5pYWGhzW00YJr0=
+golang.org/x/mod v0.14.0/go.mod h1:hTbmBsO62+eylJbnUtE2MGJUyE7QWk4xUqPFrRgJ+7c=
+
+go.sum database tree
+24719953
+hash
+`
+	record, err := parseLookup("golang.org/x/mod", "v0.14.0", []byte(response))
+	if err != nil {
+		t.Fatalf("parseLookup failed: %v", err)
+	}
+	want := sumDBRecord{
+		Module:    "golang.org/x/mod",
+		Version:   "v0.14.0",
+		RecordID:  24719953,
+		ZipHash:   "h1:dGoOF9QVLYng8IHTm7BAyWqCqSheQ5pYWGhzW00YJr0=",
+		GoModHash: "h1:hTbmBsO62+eylJbnUtE2MGJUyE7QWk4xUqPFrRgJ+7c=",
+	}
+	if *record != want {
+		t.Errorf("parseLookup = %+v, want %+v", *record, want)
+	}
+
+	for _, bad := range []string{
+		"not a number\n",
+		"1\nexample.com/other v0.14.0 h1:x=\n",
+		"1\ngolang.org/x/mod v0.14.0\n",
+		"1\n\ngo.sum database tree\n",
+	} {
+		if _, err := parseLookup("golang.org/x/mod", "v0.14.0", []byte(bad)); err == nil {
+			t.Errorf("parseLookup(%q) succeeded, want an error", bad)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.bytecodealliance.org/cm"
+)
+
+type GetPublishTimelineResult = cm.Result[string, string, string]
+type GetReleaseSummaryResult = cm.Result[string, string, string]
+
+// timelineWorkers bounds how many .info records getPublishTimeline fetches
+// at once.
+const timelineWorkers = 8
+
+// timelineMargin is how many versions below the limit getPublishTimeline
+// fetches besides the limit highest ones, as the most recently published
+// versions are not always the highest: a patch to an older minor can come
+// out after a newer minor.
+const timelineMargin = 10
+
+// timelineEntry is one published version. Error is set instead of Time
+// when the version's .info record could not be fetched.
+type timelineEntry struct {
+	Version string     `json:"version"`
+	Time    *time.Time `json:"time,omitempty"`
+	Error   string     `json:"error,omitempty"`
+}
+
+// getPublishTimeline lists every published version of moduleName with its
+// publish time, oldest first, keeping only the limit most recent versions
+// when limit is non-zero. Only the .info records of the limit highest
+// versions, plus timelineMargin more, are fetched then. Versions whose time
+// could not be fetched are listed last with an error.
+func getPublishTimeline(ctx context.Context, moduleName string, limit uint32) GetPublishTimelineResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[GetPublishTimelineResult](invalidInput("Module name must not be empty"))
+	}
+
+	data, err := proxyRequest(ctx, escapedListPath(moduleName))
+	if err != nil {
+		if isNotFound(err) {
+			return fail[GetPublishTimelineResult](fmt.Errorf("Module %s not found on the proxy: %w", moduleName, err))
+		}
+		return fail[GetPublishTimelineResult](fmt.Errorf("Failed to fetch %s: %w", moduleName, err))
+	}
+
+	versions := parseVersionList(data)
+	sortVersions(versions)
+	if fetch := int(limit) + timelineMargin; limit > 0 && fetch < len(versions) {
+		versions = versions[len(versions)-fetch:]
+	}
+
+	entries := make([]timelineEntry, len(versions))
+	forEachModuleLimit(ctx, versions, timelineWorkers, func(i int, version string) {
+		entries[i].Version = version
+		info, err := fetchVersionInfo(ctx, moduleName, version)
+		if err != nil {
+			entries[i].Error = err.Error()
+			return
+		}
+		entries[i].Time = &info.Time
+	})
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i].Time, entries[j].Time
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		return a.Before(*b)
+	})
+
+	if limit > 0 {
+		dated := 0
+		for dated < len(entries) && entries[dated].Time != nil {
+			dated++
+		}
+		if int(limit) < dated {
+			entries = entries[dated-int(limit):]
+		}
+	}
+
+	jsonData, err := json.Marshal(entries)
+	if err != nil {
+		return fail[GetPublishTimelineResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[GetPublishTimelineResult](string(jsonData))
+}
+
+// releaseSummary is how many versions a module has published and when its
+// first and latest ones were released. The first release fields are omitted
+// for modules without tagged versions, whose latest version is then the
+// pseudo-version reported by @latest.
+type releaseSummary struct {
+	Versions        int        `json:"versions"`
+	FirstVersion    string     `json:"first_version,omitempty"`
+	FirstPublished  *time.Time `json:"first_published,omitempty"`
+	LatestVersion   string     `json:"latest_version"`
+	LatestPublished time.Time  `json:"latest_published"`
+}
+
+// getReleaseSummary reports, for every module, the number of published
+// versions and the version and date of its first and latest releases, from
+// @v/list and the .info records of the lowest and highest versions.
+func getReleaseSummary(ctx context.Context, moduleNames string) GetReleaseSummaryResult {
+	modules, invalid, err := normalizeModules(moduleNames)
+	if err != nil {
+		return fail[GetReleaseSummaryResult](err)
+	}
+	summaries := make([]*releaseSummary, len(modules))
+	failures := make([]error, len(modules))
+
+	forEachModule(ctx, modules, func(i int, moduleName string) {
+		summaries[i], failures[i] = summarizeReleases(ctx, moduleName)
+	})
+
+	batch := newBatchResult(invalid)
+	for i, moduleName := range modules {
+		if failures[i] != nil {
+			batch.addError(moduleName, failures[i])
+			continue
+		}
+		batch.add(moduleName, summaries[i])
+	}
+	return batchOutput[GetReleaseSummaryResult](batch, "get release summaries")
+}
+
+// summarizeReleases builds the releaseSummary of moduleName, fetching the
+// .info records of its first and latest versions concurrently.
+func summarizeReleases(ctx context.Context, moduleName string) (*releaseSummary, error) {
+	data, err := proxyRequest(ctx, escapedListPath(moduleName))
+	if err != nil {
+		if isNotFound(err) {
+			return nil, describe(err, "module not found: %s", moduleName)
+		}
+		return nil, fmt.Errorf("failed to fetch version list: %w", err)
+	}
+
+	versions := parseVersionList(data)
+	if len(versions) == 0 {
+		info, err := fetchLatestInfo(ctx, moduleName)
+		if err != nil {
+			return nil, err
+		}
+		return &releaseSummary{LatestVersion: info.Version, LatestPublished: info.Time}, nil
+	}
+	sortVersions(versions)
+
+	ends := []string{versions[0]}
+	if last := versions[len(versions)-1]; last != versions[0] {
+		ends = append(ends, last)
+	}
+	infos := make([]*versionInfo, len(ends))
+	failures := make([]error, len(ends))
+	forEachModule(ctx, ends, func(i int, version string) {
+		infos[i], failures[i] = fetchVersionInfo(ctx, moduleName, version)
+	})
+	for _, err := range failures {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	first, latest := infos[0], infos[len(infos)-1]
+	return &releaseSummary{
+		Versions:        len(versions),
+		FirstVersion:    first.Version,
+		FirstPublished:  &first.Time,
+		LatestVersion:   latest.Version,
+		LatestPublished: latest.Time,
+	}, nil
+}
@@ -0,0 +1,59 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestGetPublishTimelineLimit checks that a limited timeline only fetches
+// the .info records of the highest versions.
+func TestGetPublishTimelineLimit(t *testing.T) {
+	var list strings.Builder
+	for minor := 0; minor < 30; minor++ {
+		fmt.Fprintf(&list, "v1.%d.0\n", minor)
+	}
+	published := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var infos atomic.Int32
+	fakeTransport(t, func(req *http.Request) (*http.Response, error) {
+		path := strings.TrimPrefix(req.URL.Path, "/example.com/m/@v/")
+		if path == "list" {
+			return textResponse(req, http.StatusOK, list.String()), nil
+		}
+		infos.Add(1)
+		version := strings.TrimSuffix(path, ".info")
+		var minor int
+		fmt.Sscanf(version, "v1.%d.0", &minor)
+		info := fmt.Sprintf(`{"Version":%q,"Time":%q}`, version, published.AddDate(0, 0, minor).Format(time.RFC3339))
+		return textResponse(req, http.StatusOK, info), nil
+	})
+
+	result := getPublishTimeline(context.Background(), "example.com/m", 3)
+	if result.IsErr() {
+		t.Fatalf("getPublishTimeline failed: %s", *result.Err())
+	}
+	var entries []timelineEntry
+	if err := json.Unmarshal([]byte(*result.OK()), &entries); err != nil {
+		t.Fatalf("failed to parse timeline: %v", err)
+	}
+
+	var got []string
+	for _, entry := range entries {
+		got = append(got, entry.Version)
+	}
+	if want := "v1.27.0 v1.28.0 v1.29.0"; strings.Join(got, " ") != want {
+		t.Errorf("timeline = %v, want %s", got, want)
+	}
+	if n := infos.Load(); n != 3+timelineMargin {
+		t.Errorf("fetched %d .info records, want %d", n, 3+timelineMargin)
+	}
+}
@@ -0,0 +1,12 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+//go:build !wasip2
+
+package main
+
+import "net/http"
+
+// transport is the standard library's outside wasip2, so that the package
+// builds and its tests run on the host.
+var transport http.RoundTripper = http.DefaultTransport
@@ -0,0 +1,15 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+//go:build wasip2
+
+package main
+
+import (
+	"net/http"
+
+	wasihttp "github.com/ydnar/wasi-http-go/wasihttp"
+)
+
+// transport sends requests through the host's wasi:http implementation.
+var transport http.RoundTripper = &wasihttp.Transport{}
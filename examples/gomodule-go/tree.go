@@ -0,0 +1,180 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"go.bytecodealliance.org/cm"
+)
+
+type GetDependencyTreeResult = cm.Result[string, string, string]
+
+const (
+	// defaultTreeDepth is how many levels of requirements getDependencyTree
+	// walks when no depth is given.
+	defaultTreeDepth = 3
+	// maxTreeDepth bounds the depth a caller may request.
+	maxTreeDepth = 10
+	// defaultTreeFetchLimit bounds how many go.mod files one tree walk
+	// downloads unless overridden by GOMODULE_TREE_FETCH_LIMIT.
+	defaultTreeFetchLimit = 200
+)
+
+// treeFetchLimit is the number of go.mod files a tree walk may fetch.
+var treeFetchLimit = defaultTreeFetchLimit
+
+func init() {
+	if value := os.Getenv("GOMODULE_TREE_FETCH_LIMIT"); value != "" {
+		if limit, err := strconv.Atoi(value); err == nil && limit > 0 {
+			treeFetchLimit = limit
+		}
+	}
+}
+
+// dependencyNode is a module version in a dependency tree. Stopped explains
+// why a node has no children listed: "depth" when the depth bound was
+// reached, "cycle" when the module is already one of its own ancestors,
+// "fetch_limit" when the walk ran out of fetches and "cancelled" when the
+// call was cancelled before the node was reached.
+type dependencyNode struct {
+	Path     string            `json:"path"`
+	Version  string            `json:"version"`
+	Indirect bool              `json:"indirect,omitempty"`
+	Children []*dependencyNode `json:"children,omitempty"`
+	Stopped  string            `json:"stopped,omitempty"`
+	Error    string            `json:"error,omitempty"`
+	// err is the failure Error describes, kept for classifyError.
+	err error
+}
+
+// dependencyTree is the result of getDependencyTree.
+type dependencyTree struct {
+	Root         *dependencyNode `json:"root"`
+	Depth        int             `json:"depth"`
+	Fetches      int             `json:"fetches"`
+	FetchLimit   int             `json:"fetch_limit"`
+	LimitReached bool            `json:"limit_reached"`
+}
+
+// treeWalker walks require directives, fetching each module version's
+// go.mod at most once so diamond dependencies cost a single request, and at
+// most limit go.mod files in total.
+type treeWalker struct {
+	requires     map[string][]dependency
+	limit        int
+	fetches      int
+	limitReached bool
+}
+
+// getDependencyTree returns the requirement tree of moduleName at version
+// (latest when empty), depth levels deep (defaultTreeDepth when 0).
+func getDependencyTree(ctx context.Context, moduleName, version string, depth uint32) GetDependencyTreeResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[GetDependencyTreeResult](invalidInput("Module name must not be empty"))
+	}
+
+	if depth == 0 {
+		depth = defaultTreeDepth
+	}
+	if depth > maxTreeDepth {
+		return fail[GetDependencyTreeResult](invalidInput("Depth must be at most %d", maxTreeDepth))
+	}
+
+	if version == "" {
+		latest, err := resolveLatestVersion(ctx, moduleName)
+		if err != nil {
+			return fail[GetDependencyTreeResult](err)
+		}
+		version = latest
+	}
+
+	walker := &treeWalker{requires: make(map[string][]dependency), limit: treeFetchLimit}
+	root := &dependencyNode{Path: moduleName, Version: version}
+	walker.expand(ctx, root, int(depth), map[string]bool{})
+	if root.err != nil {
+		return fail[GetDependencyTreeResult](root.err)
+	}
+
+	jsonData, err := json.Marshal(dependencyTree{
+		Root:         root,
+		Depth:        int(depth),
+		Fetches:      walker.fetches,
+		FetchLimit:   treeFetchLimit,
+		LimitReached: walker.limitReached,
+	})
+	if err != nil {
+		return fail[GetDependencyTreeResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[GetDependencyTreeResult](string(jsonData))
+}
+
+// expand fills in node's children up to depth levels below it. ancestors
+// holds the module paths on the way from the root to node.
+func (w *treeWalker) expand(ctx context.Context, node *dependencyNode, depth int, ancestors map[string]bool) {
+	if ctx.Err() != nil {
+		node.Stopped = "cancelled"
+		return
+	}
+	if ancestors[node.Path] {
+		node.Stopped = "cycle"
+		return
+	}
+	if depth == 0 {
+		node.Stopped = "depth"
+		return
+	}
+
+	requires, err := w.fetchRequires(ctx, node.Path, node.Version)
+	if err != nil {
+		node.err = err
+		node.Error = err.Error()
+		return
+	}
+	if requires == nil {
+		node.Stopped = "fetch_limit"
+		return
+	}
+
+	ancestors[node.Path] = true
+	for _, req := range requires {
+		child := &dependencyNode{Path: req.Path, Version: req.Version, Indirect: req.Indirect}
+		w.expand(ctx, child, depth-1, ancestors)
+		node.Children = append(node.Children, child)
+	}
+	delete(ancestors, node.Path)
+}
+
+// fetchRequires returns the requirements of moduleName at version, or nil
+// without error when the fetch limit has been reached.
+func (w *treeWalker) fetchRequires(ctx context.Context, moduleName, version string) ([]dependency, error) {
+	key := moduleName + "@" + version
+	if requires, ok := w.requires[key]; ok {
+		return requires, nil
+	}
+
+	if w.fetches >= w.limit {
+		w.limitReached = true
+		return nil, nil
+	}
+	w.fetches++
+
+	file, _, err := fetchModFile(ctx, moduleName, version)
+	if err != nil {
+		return nil, err
+	}
+
+	requires := []dependency{}
+	for _, req := range file.Require {
+		requires = append(requires, dependency{Path: req.Mod.Path, Version: req.Mod.Version, Indirect: req.Indirect})
+	}
+	w.requires[key] = requires
+	return requires, nil
+}
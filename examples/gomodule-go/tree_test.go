@@ -0,0 +1,24 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+func TestExpandStopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	walker := &treeWalker{requires: make(map[string][]dependency), limit: 10}
+	node := &dependencyNode{Path: "example.com/m", Version: "v1.0.0"}
+	walker.expand(ctx, node, 3, map[string]bool{})
+	if node.Stopped != "cancelled" {
+		t.Errorf("Stopped = %q, want %q", node.Stopped, "cancelled")
+	}
+	if walker.fetches != 0 {
+		t.Errorf("fetches = %d, want 0", walker.fetches)
+	}
+}
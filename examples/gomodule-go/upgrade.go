@@ -0,0 +1,202 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.bytecodealliance.org/cm"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+type CheckUpgradeResult = cm.Result[string, string, string]
+type GetLatestPatchResult = cm.Result[string, string, string]
+
+// upgradeTarget is the newest tagged release in the range an upgrade may
+// move within. AlreadyLatest is set when nothing in the range is newer than
+// the pinned version, in which case Version is the pinned version.
+type upgradeTarget struct {
+	Version       string `json:"version"`
+	AlreadyLatest bool   `json:"already_latest"`
+}
+
+// upgradeCheck is the result of checkUpgrade.
+type upgradeCheck struct {
+	Module  string `json:"module"`
+	Version string `json:"version"`
+	Pseudo  bool   `json:"pseudo"`
+	// NearestTag is the release a pseudo-version was derived from; it is
+	// omitted for tagged pins and for pseudo-versions predating any tag.
+	NearestTag  string        `json:"nearest_tag,omitempty"`
+	LatestPatch upgradeTarget `json:"latest_patch"`
+	LatestMinor upgradeTarget `json:"latest_minor"`
+	// NewerMajor is the latest release under the highest major version
+	// module path, or nil when the pinned module path is the highest.
+	NewerMajor *majorRelease `json:"newer_major"`
+}
+
+// checkUpgrade reports the upgrades available to spec, a "module@version"
+// pin: the newest patch of the same minor, the newest minor of the same
+// major, and the newest major version module path. Prereleases and
+// pseudo-versions are never suggested.
+func checkUpgrade(ctx context.Context, spec string) CheckUpgradeResult {
+	moduleName, version, ok := strings.Cut(strings.TrimSpace(spec), "@")
+	moduleName = defaultModulePath(moduleName)
+	if !ok || moduleName == "" {
+		return fail[CheckUpgradeResult](invalidInput("Invalid spec %q: expected module@version", spec))
+	}
+
+	version = canonicalVersion(version)
+	if !semver.IsValid(version) {
+		return fail[CheckUpgradeResult](invalidInput("Invalid semantic version: %q", version))
+	}
+
+	data, err := proxyRequest(ctx, escapedListPath(moduleName))
+	if err != nil {
+		if isNotFound(err) {
+			return fail[CheckUpgradeResult](fmt.Errorf("Module %s not found on the proxy: %w", moduleName, err))
+		}
+		return fail[CheckUpgradeResult](fmt.Errorf("Failed to fetch %s: %w", moduleName, err))
+	}
+	versions := parseVersionList(data)
+
+	latest, err := findLatestMajor(ctx, moduleName)
+	if err != nil {
+		return fail[CheckUpgradeResult](err)
+	}
+
+	result := upgradeCheck{
+		Module:  moduleName,
+		Version: version,
+		Pseudo:  module.IsPseudoVersion(version),
+		LatestPatch: newestWithin(versions, version, func(v string) bool {
+			return semver.MajorMinor(v) == semver.MajorMinor(version)
+		}),
+		LatestMinor: newestWithin(versions, version, func(v string) bool {
+			return semver.Major(v) == semver.Major(version)
+		}),
+	}
+	if result.Pseudo {
+		// An error means the pseudo-version predates every tag.
+		if base, err := module.PseudoVersionBase(version); err == nil {
+			result.NearestTag = base
+		}
+	}
+	if latest.Module != moduleName {
+		info, err := fetchLatestInfo(ctx, latest.Module)
+		if err != nil {
+			return fail[CheckUpgradeResult](err)
+		}
+		result.NewerMajor = &majorRelease{Module: latest.Module, Version: info.Version, Time: info.Time}
+	}
+
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		return fail[CheckUpgradeResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[CheckUpgradeResult](string(jsonData))
+}
+
+// newestWithin returns the newest tagged release in versions that satisfies
+// within and is newer than pinned.
+func newestWithin(versions []string, pinned string, within func(string) bool) upgradeTarget {
+	var candidates []string
+	for _, v := range versions {
+		if within(v) && semver.Compare(v, pinned) > 0 {
+			candidates = append(candidates, v)
+		}
+	}
+
+	best, ok := latestStable(candidates)
+	if !ok {
+		return upgradeTarget{Version: pinned, AlreadyLatest: true}
+	}
+	return upgradeTarget{Version: best}
+}
+
+// latestPatch is the result of getLatestPatch. When no version of the
+// major.minor line qualifies, Found is false, Message says why, and
+// AvailableLines lists the lines the module does have.
+type latestPatch struct {
+	Module         string   `json:"module"`
+	Line           string   `json:"line"`
+	Found          bool     `json:"found"`
+	Version        string   `json:"version,omitempty"`
+	Message        string   `json:"message,omitempty"`
+	AvailableLines []string `json:"available_lines,omitempty"`
+}
+
+// getLatestPatch returns the newest patch release of the majorMinor line
+// ("v1.4" or "1.4"; a full version like v1.4.2 names its line) of
+// moduleName. Prereleases are skipped unless includePrerelease is set;
+// pseudo-versions never count.
+func getLatestPatch(ctx context.Context, moduleName, majorMinor string, includePrerelease bool) GetLatestPatchResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[GetLatestPatchResult](invalidInput("Module name must not be empty"))
+	}
+
+	line := canonicalVersion(majorMinor)
+	if !semver.IsValid(line) || !strings.Contains(line, ".") {
+		return fail[GetLatestPatchResult](invalidInput("Invalid major.minor version %q: expected a version like v1.4", majorMinor))
+	}
+	line = semver.MajorMinor(line)
+
+	data, err := proxyRequest(ctx, escapedListPath(moduleName))
+	if err != nil {
+		if isNotFound(err) {
+			return fail[GetLatestPatchResult](fmt.Errorf("Module %s not found on the proxy: %w", moduleName, err))
+		}
+		return fail[GetLatestPatchResult](fmt.Errorf("Failed to fetch %s: %w", moduleName, err))
+	}
+
+	result := latestPatch{Module: moduleName, Line: line}
+	lines := map[string]bool{}
+	var matching []string
+	for _, v := range parseVersionList(data) {
+		if !semver.IsValid(v) || module.IsPseudoVersion(v) {
+			continue
+		}
+		lines[semver.MajorMinor(v)] = true
+		if semver.MajorMinor(v) == line {
+			matching = append(matching, v)
+		}
+	}
+
+	best, ok := latestStable(matching)
+	if !ok && includePrerelease {
+		best, ok = latestPrerelease(matching)
+	}
+	switch {
+	case ok:
+		result.Found = true
+		result.Version = best
+	case len(matching) > 0:
+		result.Message = fmt.Sprintf("%s only has prereleases in the %s line; set include-prerelease to consider them", moduleName, line)
+	default:
+		for l := range lines {
+			result.AvailableLines = append(result.AvailableLines, l)
+		}
+		sort.Slice(result.AvailableLines, func(i, j int) bool {
+			return semver.Compare(result.AvailableLines[i], result.AvailableLines[j]) < 0
+		})
+		result.Message = fmt.Sprintf("%s has no versions in the %s line", moduleName, line)
+		if len(result.AvailableLines) > 0 {
+			result.Message += "; available lines: " + strings.Join(result.AvailableLines, ", ")
+		}
+	}
+
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		return fail[GetLatestPatchResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[GetLatestPatchResult](string(jsonData))
+}
@@ -0,0 +1,80 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// maxVanityHops bounds how many go-import meta tags resolveVanity follows
+// for one module, in case vanity pages point at each other.
+const maxVanityHops = 3
+
+// fetchLatestModules is fetchModules for @latest, falling back to
+// resolveVanity for modules the proxy does not know. Results stay keyed by
+// the requested module path; their path field names the resolved one.
+func fetchLatestModules(ctx context.Context, modules []string) []fetchResult {
+	results := fetchModules(ctx, modules, escapedLatestPath)
+
+	forEachModule(ctx, modules, func(i int, moduleName string) {
+		if !isNotFound(results[i].err) {
+			return
+		}
+		if resolved := resolveVanity(ctx, moduleName); resolved.err == nil {
+			results[i] = resolved
+		} else {
+			logger.Debug("vanity fallback failed", "module", moduleName, "error", resolved.err)
+		}
+	})
+
+	return results
+}
+
+// resolveVanity fetches @latest of a vanity import path the proxy does not
+// serve by following its go-import meta tag: "mod" tags name a module proxy
+// that is asked directly, and VCS tags name a repository whose path is
+// retried on the proxy. Paths already tried are not probed again. The
+// result is keyed by moduleName, with path set to where @latest was found.
+func resolveVanity(ctx context.Context, moduleName string) fetchResult {
+	failed := func(err error) fetchResult {
+		return fetchResult{module: moduleName, path: moduleName, err: err}
+	}
+
+	path := moduleName
+	seen := map[string]bool{path: true}
+	for hop := 0; hop < maxVanityHops; hop++ {
+		meta, err := fetchGoImportMeta(ctx, path)
+		if err != nil {
+			return failed(err)
+		}
+
+		if meta.VCS == "mod" {
+			proxy := strings.TrimSuffix(meta.RepoURL, "/")
+			data, err := httpRequest(ctx, proxy+"/"+escapedLatestPath(path))
+			return fetchResult{module: moduleName, path: path, vanityProxy: proxy, data: data, err: err}
+		}
+
+		parsed, err := url.Parse(meta.RepoURL)
+		if err != nil || parsed.Host == "" {
+			return failed(fmt.Errorf("go-import repository %q of %s is not a URL", meta.RepoURL, path))
+		}
+		candidate := strings.ToLower(parsed.Host) + strings.TrimSuffix(strings.TrimRight(parsed.Path, "/"), ".git") + strings.TrimPrefix(path, meta.Prefix)
+		if seen[candidate] {
+			return failed(fmt.Errorf("go-import metadata of %s loops back to %s", moduleName, candidate))
+		}
+		seen[candidate] = true
+
+		data, err := proxyRequest(ctx, escapedLatestPath(candidate))
+		if !isNotFound(err) {
+			logger.Debug("resolved vanity import path", "module", moduleName, "path", candidate)
+			return fetchResult{module: moduleName, path: candidate, data: data, err: err}
+		}
+		path = candidate
+	}
+
+	return failed(fmt.Errorf("gave up resolving %s after %d go-import hops", moduleName, maxVanityHops))
+}
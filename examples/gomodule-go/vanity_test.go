@@ -0,0 +1,53 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// fakeRoutes answers requests whose URL, without the scheme, is a key of
+// routes with that body, and every other request with a 404.
+func fakeRoutes(t *testing.T, routes map[string]string) {
+	fakeTransport(t, func(req *http.Request) (*http.Response, error) {
+		key := req.URL.Host + req.URL.Path
+		if req.URL.RawQuery != "" {
+			key += "?" + req.URL.RawQuery
+		}
+		if body, ok := routes[key]; ok {
+			return textResponse(req, http.StatusOK, body), nil
+		}
+		return textResponse(req, http.StatusNotFound, "not found: "+key), nil
+	})
+}
+
+// TestGetLatestVersionsVanityRetracted checks that the retraction check of a
+// module found through its go-import meta tag reads the go.mod of the
+// repository path, as the proxy has nothing under the vanity path.
+func TestGetLatestVersionsVanityRetracted(t *testing.T) {
+	fakeRoutes(t, map[string]string{
+		"go.example.org/foo?go-get=1":                     `<meta name="go-import" content="go.example.org/foo git https://github.com/example/foo.git">`,
+		"proxy.test/github.com/example/foo/@latest":       `{"Version":"v1.2.0"}`,
+		"proxy.test/github.com/example/foo/@v/list":       "v1.1.0\nv1.2.0\n",
+		"proxy.test/github.com/example/foo/@v/v1.2.0.mod": "module github.com/example/foo\n\nretract v1.2.0 // broken\n",
+	})
+
+	result := getLatestVersions(context.Background(), "go.example.org/foo", false)
+	if result.IsErr() {
+		t.Fatal(*result.Err())
+	}
+	var got partialResults
+	if err := json.Unmarshal([]byte(*result.OK()), &got); err != nil {
+		t.Fatal(err)
+	}
+	if version := got.Results["go.example.org/foo"]; version != "v1.1.0" {
+		t.Errorf("latest version = %v, want v1.1.0 (errors: %v)", version, got.Errors)
+	}
+	if skipped := got.Retracted["go.example.org/foo"]; skipped == nil || skipped.Version != "v1.2.0" {
+		t.Errorf("retracted = %+v, want v1.2.0", skipped)
+	}
+}
@@ -0,0 +1,388 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.bytecodealliance.org/cm"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+type CompareVersionsResult = cm.Result[string, string, string]
+type GetVersionInfoResult = cm.Result[string, string, string]
+type ResolveRevisionResult = cm.Result[string, string, string]
+type GetLatestStableResult = cm.Result[string, string, string]
+type GetLatestVersionsIncludingPrereleaseResult = cm.Result[string, string, string]
+
+// revisionPattern matches commit hashes, tags and branch names that the
+// proxy can resolve through @v/<rev>.info. Slashes are excluded because a
+// revision must fit in a single URL path element.
+var revisionPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._+-]*$`)
+
+// resolvedRevision is the pseudo-version (or tag) a revision maps to.
+type resolvedRevision struct {
+	Module   string    `json:"module"`
+	Revision string    `json:"revision"`
+	Version  string    `json:"version"`
+	Time     time.Time `json:"time"`
+	IsPseudo bool      `json:"is_pseudo"`
+}
+
+// versionInfo is the body of a proxy @v/<version>.info response.
+type versionInfo struct {
+	Version string
+	Time    time.Time
+	// Origin describes the VCS source of the version; older records omit it.
+	Origin json.RawMessage
+}
+
+// versionComparison is the verdict returned by compareVersions. Change
+// classifies moving from version A to version B as "same", "patch",
+// "minor", "major", "prerelease" or "downgrade".
+type versionComparison struct {
+	VersionA    string `json:"version_a"`
+	VersionB    string `json:"version_b"`
+	Newer       string `json:"newer"`
+	Bump        string `json:"bump"`
+	Change      string `json:"change"`
+	Breaking    bool   `json:"breaking"`
+	DaysBetween int    `json:"days_between"`
+}
+
+// fetchVersionInfo downloads the .info record of moduleName at version.
+func fetchVersionInfo(ctx context.Context, moduleName, version string) (*versionInfo, error) {
+	path, err := proxyVersionPath(moduleName, version, ".info")
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := proxyRequest(ctx, path)
+	if err != nil {
+		if isNotFound(err) {
+			if message := proxyMessage(err); message != "" {
+				return nil, describe(err, "version not found: version %s not found for %s: %s", version, moduleName, message)
+			}
+			return nil, describe(err, "version not found: version %s not found for %s", version, moduleName)
+		}
+		return nil, fmt.Errorf("transport failure: fetching %s@%s: %w", moduleName, version, err)
+	}
+
+	var info versionInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON for %s@%s: %w", moduleName, version, err)
+	}
+
+	return &info, nil
+}
+
+// bumpKind classifies the difference between two valid semantic versions as
+// "major", "minor", "patch", "prerelease" or "none".
+func bumpKind(a, b string) string {
+	switch {
+	case semver.Compare(a, b) == 0:
+		return "none"
+	case semver.Major(a) != semver.Major(b):
+		return "major"
+	case semver.MajorMinor(a) != semver.MajorMinor(b):
+		return "minor"
+	case releaseVersion(a) != releaseVersion(b):
+		return "patch"
+	default:
+		return "prerelease"
+	}
+}
+
+// releaseVersion strips the prerelease and build suffixes from v.
+func releaseVersion(v string) string {
+	return strings.TrimSuffix(semver.Canonical(v), semver.Prerelease(v))
+}
+
+// changeKind classifies moving from version a to version b: "same",
+// "downgrade" when b is older, and otherwise the bumpKind of the upgrade.
+func changeKind(a, b string) string {
+	switch semver.Compare(a, b) {
+	case 0:
+		return "same"
+	case 1:
+		return "downgrade"
+	default:
+		return bumpKind(a, b)
+	}
+}
+
+// compareVersions compares versionA with versionB of moduleName. An empty
+// versionB or the literal "latest" compares versionA with the version
+// @latest resolves to, which makes it an upgrade check.
+func compareVersions(ctx context.Context, moduleName, versionA, versionB string) CompareVersionsResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[CompareVersionsResult](invalidInput("Module name must not be empty"))
+	}
+
+	versionA = canonicalVersion(versionA)
+	if !semver.IsValid(versionA) {
+		return fail[CompareVersionsResult](invalidInput("Invalid semantic version: %q", versionA))
+	}
+
+	var infoB *versionInfo
+	var err error
+	if versionB = strings.TrimSpace(versionB); versionB == "" || versionB == "latest" {
+		if infoB, err = fetchLatestInfo(ctx, moduleName); err != nil {
+			return fail[CompareVersionsResult](err)
+		}
+		versionB = infoB.Version
+	} else if versionB = canonicalVersion(versionB); !semver.IsValid(versionB) {
+		return fail[CompareVersionsResult](invalidInput("Invalid semantic version: %q", versionB))
+	}
+
+	infoA, err := fetchVersionInfo(ctx, moduleName, versionA)
+	if err != nil {
+		return fail[CompareVersionsResult](err)
+	}
+
+	if infoB == nil {
+		if infoB, err = fetchVersionInfo(ctx, moduleName, versionB); err != nil {
+			return fail[CompareVersionsResult](err)
+		}
+	}
+
+	change := changeKind(versionA, versionB)
+	result := versionComparison{
+		VersionA:    versionA,
+		VersionB:    versionB,
+		Bump:        bumpKind(versionA, versionB),
+		Change:      change,
+		Breaking:    change == "major",
+		DaysBetween: int(math.Abs(infoB.Time.Sub(infoA.Time).Hours()) / 24),
+	}
+
+	switch semver.Compare(versionA, versionB) {
+	case 1:
+		result.Newer = versionA
+	case -1:
+		result.Newer = versionB
+	}
+
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		return fail[CompareVersionsResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[CompareVersionsResult](string(jsonData))
+}
+
+// sortVersions sorts versions in ascending semantic-version order, so the
+// newest release is last and prereleases precede their release
+// (v1.2.0-rc1 < v1.2.0). Versions that compare equal, such as those that
+// differ only in +incompatible or other build metadata, are ordered
+// lexically, and invalid versions sort first, so the order is deterministic.
+func sortVersions(versions []string) {
+	semver.Sort(versions)
+}
+
+// canonicalVersion adds the leading "v" to shorthand versions like 1.2.3.
+func canonicalVersion(version string) string {
+	version = strings.TrimSpace(version)
+	if version != "" && !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	return version
+}
+
+// getVersionInfo returns the .info record of moduleName at version. The
+// literal "latest" is answered from the @latest endpoint instead.
+func getVersionInfo(ctx context.Context, moduleName, version string) GetVersionInfoResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[GetVersionInfoResult](invalidInput("Module name must not be empty"))
+	}
+
+	var info *versionInfo
+	var err error
+	if version = strings.TrimSpace(version); version == "latest" {
+		info, err = fetchLatestInfo(ctx, moduleName)
+	} else if version = canonicalVersion(version); version == "" {
+		return fail[GetVersionInfoResult](invalidInput("Version must not be empty"))
+	} else {
+		info, err = fetchVersionInfo(ctx, moduleName, version)
+	}
+	if err != nil {
+		return fail[GetVersionInfoResult](err)
+	}
+
+	// Some very old records have no Time; report it as null.
+	var published *time.Time
+	if !info.Time.IsZero() {
+		published = &info.Time
+	}
+
+	jsonData, err := json.Marshal(struct {
+		Version string
+		Time    *time.Time
+		Origin  json.RawMessage
+	}{info.Version, published, info.Origin})
+	if err != nil {
+		return fail[GetVersionInfoResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[GetVersionInfoResult](string(jsonData))
+}
+
+func resolveRevision(ctx context.Context, moduleName, rev string) ResolveRevisionResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[ResolveRevisionResult](invalidInput("Module name must not be empty"))
+	}
+
+	rev = strings.TrimSpace(rev)
+	if !revisionPattern.MatchString(rev) || strings.Contains(rev, "..") || strings.HasSuffix(rev, ".lock") {
+		return fail[ResolveRevisionResult](invalidInput("Invalid revision %q: expected a commit hash, tag or branch name", rev))
+	}
+
+	info, err := fetchVersionInfo(ctx, moduleName, rev)
+	if err != nil {
+		if isNotFound(err) {
+			return fail[ResolveRevisionResult](fmt.Errorf("Proxy could not resolve %s@%s (unknown revision or private repository): %w", moduleName, rev, err))
+		}
+		return fail[ResolveRevisionResult](err)
+	}
+
+	jsonData, err := json.Marshal(resolvedRevision{
+		Module:   moduleName,
+		Revision: rev,
+		Version:  info.Version,
+		Time:     info.Time,
+		IsPseudo: module.IsPseudoVersion(info.Version),
+	})
+	if err != nil {
+		return fail[ResolveRevisionResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[ResolveRevisionResult](string(jsonData))
+}
+
+// escapedListPath is the @v/list path of moduleName, case-encoded for the proxy.
+func escapedListPath(moduleName string) string {
+	return escapeModulePath(moduleName) + "/@v/list"
+}
+
+// latestStable returns the highest tagged release in versions, skipping
+// prereleases and pseudo-versions.
+func latestStable(versions []string) (string, bool) {
+	best := ""
+	for _, v := range versions {
+		if !semver.IsValid(v) || semver.Prerelease(v) != "" || module.IsPseudoVersion(v) {
+			continue
+		}
+		if best == "" || semver.Compare(v, best) > 0 {
+			best = v
+		}
+	}
+	return best, best != ""
+}
+
+// getLatestStable is getLatestVersions restricted to tagged releases: unlike
+// @latest it never resolves to a prerelease or pseudo-version.
+func getLatestStable(ctx context.Context, moduleNames string) GetLatestStableResult {
+	modules, invalid, err := normalizeModules(moduleNames)
+	if err != nil {
+		return fail[GetLatestStableResult](err)
+	}
+	batch := newBatchResult(invalid)
+
+	for _, fetched := range fetchModules(ctx, modules, escapedListPath) {
+		if fetched.err != nil {
+			batch.addError(fetched.module, fmt.Errorf("failed to fetch: %w", fetched.err))
+		} else if version, ok := latestStable(parseVersionList(fetched.data)); ok {
+			batch.add(fetched.module, version)
+		} else {
+			batch.addError(fetched.module, errors.New("no stable release: only prerelease or pseudo-versions are available"))
+		}
+	}
+	return batchOutput[GetLatestStableResult](batch, "get latest stable versions")
+}
+
+// latestVersions pairs a module's latest stable release with a newer
+// prerelease. Stable is false when the module has no tagged release, in
+// which case Latest is what @latest resolves to: its newest prerelease or
+// pseudo-version.
+type latestVersions struct {
+	Latest           string `json:"latest"`
+	LatestPrerelease string `json:"latest_prerelease,omitempty"`
+	Stable           bool   `json:"stable"`
+}
+
+// latestPrerelease returns the highest tagged prerelease in versions,
+// skipping pseudo-versions.
+func latestPrerelease(versions []string) (string, bool) {
+	best := ""
+	for _, v := range versions {
+		if !semver.IsValid(v) || semver.Prerelease(v) == "" || module.IsPseudoVersion(v) {
+			continue
+		}
+		if best == "" || semver.Compare(v, best) > 0 {
+			best = v
+		}
+	}
+	return best, best != ""
+}
+
+// getLatestVersionsIncludingPrerelease reports the latest stable release of
+// every module from its @v/list, together with the newest prerelease when
+// that is newer, which @latest would hide.
+func getLatestVersionsIncludingPrerelease(ctx context.Context, moduleNames string) GetLatestVersionsIncludingPrereleaseResult {
+	modules, invalid, err := normalizeModules(moduleNames)
+	if err != nil {
+		return fail[GetLatestVersionsIncludingPrereleaseResult](err)
+	}
+	reports := make([]*latestVersions, len(modules))
+	failures := make([]error, len(modules))
+
+	forEachModule(ctx, modules, func(i int, moduleName string) {
+		data, err := proxyRequest(ctx, escapedListPath(moduleName))
+		if err != nil {
+			failures[i] = fmt.Errorf("failed to fetch: %w", err)
+			return
+		}
+
+		versions := parseVersionList(data)
+		stable, hasStable := latestStable(versions)
+		prerelease, hasPrerelease := latestPrerelease(versions)
+
+		report := &latestVersions{Latest: stable, Stable: hasStable}
+		if !hasStable {
+			// Without a tagged release, @latest falls back to the newest
+			// prerelease or, failing that, a pseudo-version.
+			info, err := fetchLatestInfo(ctx, moduleName)
+			if err != nil {
+				failures[i] = err
+				return
+			}
+			report.Latest = info.Version
+		}
+		if hasPrerelease && semver.Compare(prerelease, report.Latest) > 0 {
+			report.LatestPrerelease = prerelease
+		}
+		reports[i] = report
+	})
+
+	batch := newBatchResult(invalid)
+	for i, moduleName := range modules {
+		if failures[i] != nil {
+			batch.addError(moduleName, failures[i])
+			continue
+		}
+		batch.add(moduleName, reports[i])
+	}
+	return batchOutput[GetLatestVersionsIncludingPrereleaseResult](batch, "get latest versions")
+}
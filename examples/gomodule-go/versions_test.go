@@ -0,0 +1,33 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import "testing"
+
+func TestRevisionPattern(t *testing.T) {
+	for _, rev := range []string{"main", "1234567", "abcdef123456", "release-1.2", "v1.2.3", "feature_x"} {
+		if !revisionPattern.MatchString(rev) {
+			t.Errorf("revisionPattern rejects %q", rev)
+		}
+	}
+	for _, rev := range []string{"", "-main", "a b", "a/b", "a:b"} {
+		if revisionPattern.MatchString(rev) {
+			t.Errorf("revisionPattern accepts %q", rev)
+		}
+	}
+}
+
+func TestCanonicalVersion(t *testing.T) {
+	tests := []struct{ version, want string }{
+		{"1.2.3", "v1.2.3"},
+		{"v1.2.3", "v1.2.3"},
+		{" 1.2 ", "v1.2"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := canonicalVersion(tt.version); got != tt.want {
+			t.Errorf("canonicalVersion(%q) = %q, want %q", tt.version, got, tt.want)
+		}
+	}
+}
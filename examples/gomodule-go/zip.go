@@ -0,0 +1,268 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"go.bytecodealliance.org/cm"
+	"golang.org/x/mod/sumdb/dirhash"
+)
+
+// defaultMaxZipSize bounds module zip downloads unless overridden by the
+// GOMODULE_MAX_ZIP_SIZE environment variable. The proxy itself refuses zips
+// over 500 MiB, but most are a few megabytes.
+const defaultMaxZipSize = 64 << 20
+
+// maxZipSize is the largest module zip, in bytes, the component downloads.
+var maxZipSize int64 = defaultMaxZipSize
+
+func init() {
+	if value := os.Getenv("GOMODULE_MAX_ZIP_SIZE"); value != "" {
+		if size, err := strconv.ParseInt(value, 10, 64); err == nil && size > 0 {
+			maxZipSize = size
+		}
+	}
+}
+
+type ListModuleFilesResult = cm.Result[string, string, string]
+type GetModuleSizeResult = cm.Result[string, string, string]
+
+// maxListedFiles bounds how many entries listModuleFiles returns.
+const maxListedFiles = 2000
+
+// zipEntry is a file in a module zip.
+type zipEntry struct {
+	Path string `json:"path"`
+	Size uint64 `json:"size"`
+}
+
+// moduleFiles is the result of listModuleFiles.
+type moduleFiles struct {
+	Module     string     `json:"module"`
+	Version    string     `json:"version"`
+	Files      []zipEntry `json:"files"`
+	TotalFiles int        `json:"total_files"`
+	Truncated  bool       `json:"truncated"`
+}
+
+// listModuleFiles lists the files in the module zip of moduleName at version
+// (latest when empty), relative to the module root, with their uncompressed
+// sizes.
+func listModuleFiles(ctx context.Context, moduleName, version string) ListModuleFilesResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[ListModuleFilesResult](invalidInput("Module name must not be empty"))
+	}
+
+	if version == "" {
+		latest, err := resolveLatestVersion(ctx, moduleName)
+		if err != nil {
+			return fail[ListModuleFilesResult](err)
+		}
+		version = latest
+	}
+
+	files, err := openModuleZip(ctx, moduleName, version)
+	if err != nil {
+		return fail[ListModuleFilesResult](err)
+	}
+
+	result := moduleFiles{Module: moduleName, Version: version, Files: []zipEntry{}, TotalFiles: len(files)}
+	for _, file := range files {
+		if len(result.Files) == maxListedFiles {
+			result.Truncated = true
+			break
+		}
+		result.Files = append(result.Files, zipEntry{
+			Path: file.Path,
+			Size: file.UncompressedSize64,
+		})
+	}
+
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		return fail[ListModuleFilesResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[ListModuleFilesResult](string(jsonData))
+}
+
+// moduleSize is the result of getModuleSize.
+type moduleSize struct {
+	Module    string `json:"module"`
+	Version   string `json:"version"`
+	SizeBytes int64  `json:"size_bytes"`
+	Size      string `json:"size"`
+	// Source is "content-length" when the proxy reported the size, or
+	// "measured" when it sent no Content-Length and the zip was counted
+	// while streaming.
+	Source string `json:"source"`
+}
+
+// getModuleSize reports the download size of the module zip of moduleName at
+// version (latest when empty) from the proxy's Content-Length, without
+// downloading the zip unless the proxy does not send one.
+func getModuleSize(ctx context.Context, moduleName, version string) GetModuleSizeResult {
+	moduleName = defaultModulePath(moduleName)
+	if moduleName == "" {
+		return fail[GetModuleSizeResult](invalidInput("Module name must not be empty"))
+	}
+
+	if version == "" {
+		latest, err := resolveLatestVersion(ctx, moduleName)
+		if err != nil {
+			return fail[GetModuleSizeResult](err)
+		}
+		version = latest
+	}
+
+	size, measured, err := zipSize(ctx, moduleName, version)
+	if err != nil {
+		return fail[GetModuleSizeResult](err)
+	}
+
+	source := "content-length"
+	if measured {
+		source = "measured"
+	}
+
+	jsonData, err := json.Marshal(moduleSize{
+		Module:    moduleName,
+		Version:   version,
+		SizeBytes: size,
+		Size:      formatBytes(size),
+		Source:    source,
+	})
+	if err != nil {
+		return fail[GetModuleSizeResult](fmt.Errorf("Failed to marshal results: %w", err))
+	}
+
+	return cm.OK[GetModuleSizeResult](string(jsonData))
+}
+
+// zipSize asks the proxy for the size of the module zip of moduleName at
+// version; measured is set when it had to be counted from the body.
+func zipSize(ctx context.Context, moduleName, version string) (size int64, measured bool, err error) {
+	path, err := zipPath(moduleName, version)
+	if err != nil {
+		return 0, false, err
+	}
+
+	_, err = proxyFetch(path, func(url string) ([]byte, error) {
+		var err error
+		size, measured, err = httpContentLength(ctx, url)
+		return nil, err
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return 0, false, describe(err, "version not found: version %s not found for %s", version, moduleName)
+		}
+		return 0, false, fmt.Errorf("transport failure: fetching %s@%s zip: %w", moduleName, version, err)
+	}
+
+	return size, measured, nil
+}
+
+// formatBytes renders n in binary units, e.g. "1.5 MiB".
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// zipPath is the escaped proxy path of the module zip of moduleName at version.
+func zipPath(moduleName, version string) (string, error) {
+	return proxyVersionPath(moduleName, version, ".zip")
+}
+
+// fetchModuleZip downloads the module zip of moduleName at version, failing
+// when it is larger than maxZipSize.
+func fetchModuleZip(ctx context.Context, moduleName, version string) ([]byte, error) {
+	path, err := zipPath(moduleName, version)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := proxyRequestLimit(ctx, path, maxZipSize)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, describe(err, "version not found: version %s not found for %s", version, moduleName)
+		}
+		return nil, fmt.Errorf("transport failure: fetching %s@%s zip: %w", moduleName, version, err)
+	}
+
+	return data, nil
+}
+
+// moduleZipFile is a file in a module zip with its path relative to the
+// module root.
+type moduleZipFile struct {
+	*zip.File
+	Path string
+}
+
+// openModuleZip downloads the module zip of moduleName at version and
+// returns its files in zip order.
+func openModuleZip(ctx context.Context, moduleName, version string) ([]moduleZipFile, error) {
+	data, err := fetchModuleZip(ctx, moduleName, version)
+	if err != nil {
+		return nil, err
+	}
+
+	z, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("Invalid module zip for %s@%s: %w", moduleName, version, err)
+	}
+
+	// Every file in a module zip lives under a "<module>@<version>/" prefix.
+	prefix := moduleName + "@" + version + "/"
+	files := make([]moduleZipFile, len(z.File))
+	for i, file := range z.File {
+		files[i] = moduleZipFile{File: file, Path: strings.TrimPrefix(file.Name, prefix)}
+	}
+	return files, nil
+}
+
+// hashZip computes the h1: hash of an in-memory module zip, as
+// dirhash.HashZip does for zip files on disk.
+func hashZip(data []byte) (string, error) {
+	z, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("invalid module zip: %w", err)
+	}
+
+	var files []string
+	zfiles := make(map[string]*zip.File)
+	for _, file := range z.File {
+		files = append(files, file.Name)
+		zfiles[file.Name] = file
+	}
+
+	return dirhash.Hash1(files, func(name string) (io.ReadCloser, error) {
+		return zfiles[name].Open()
+	})
+}
+
+// hashGoMod computes the h1: hash recorded in go.sum for a /go.mod line.
+func hashGoMod(data []byte) (string, error) {
+	return dirhash.Hash1([]string{"go.mod"}, func(string) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	})
+}